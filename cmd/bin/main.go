@@ -17,6 +17,9 @@ func main() {
 
 	serverCmd := flag.NewFlagSet("server", flag.ExitOnError)
 	seedCmd := flag.NewFlagSet("seed", flag.ExitOnError)
+	exchangeRatesCmd := flag.NewFlagSet("exchange-rates", flag.ExitOnError)
+	sitemapCmd := flag.NewFlagSet("sitemap", flag.ExitOnError)
+	idempotencyPurgeCmd := flag.NewFlagSet("idempotency-purge", flag.ExitOnError)
 	// wsCmd := flag.NewFlagSet("ws", flag.ExitOnError)
 
 	if len(os.Args) < 2 {
@@ -28,6 +31,12 @@ func main() {
 	switch os.Args[1] {
 	case "seed":
 		cmd.RunSeed(seedCmd, os.Args[2:])
+	case "exchange-rates":
+		cmd.RunExchangeRates(exchangeRatesCmd, os.Args[2:])
+	case "sitemap":
+		cmd.RunSitemap(sitemapCmd, os.Args[2:])
+	case "idempotency-purge":
+		cmd.RunIdempotencyPurge(idempotencyPurgeCmd, os.Args[2:])
 	case "server":
 		cmd.RunServer(serverCmd, os.Args[2:])
 	default:
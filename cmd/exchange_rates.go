@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/module/exchange_rate/entity"
+	"codebase-app/internal/module/exchange_rate/repository"
+	"codebase-app/internal/module/exchange_rate/service"
+	"context"
+	"flag"
+	"os"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunExchangeRates loads exchange rates from a CSV or JSON file.
+func RunExchangeRates(cmd *flag.FlagSet, args []string) {
+	var (
+		file = cmd.String("file", "", "CSV or JSON file with base_currency, quote_currency and rate")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	if *file == "" {
+		log.Fatal().Msg("-file is required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal().Err(err).Str("file", *file).Msg("Error while opening exchange rates file")
+	}
+	defer f.Close()
+
+	rates, err := service.ParseRatesFile(*file, f)
+	if err != nil {
+		log.Fatal().Err(err).Str("file", *file).Msg("Error while parsing exchange rates file")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithShopeefunPostgres(),
+	)
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Fatal().Err(err).Msg("Error while closing database connection")
+		}
+	}()
+
+	svc := service.NewExchangeRateService(repository.NewExchangeRateRepository(adapter.Adapters.ShopeefunPostgres))
+	err = svc.SetRates(context.Background(), &entity.SetExchangeRatesRequest{
+		UserId: "file:" + *file,
+		Rates:  rates,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Any("rates", rates).Msg("Error while saving exchange rates")
+	}
+
+	log.Info().Int("rates", len(rates)).Str("file", *file).Msg("Exchange rates loaded")
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/module/idempotency/repository"
+	"codebase-app/internal/module/idempotency/service"
+	"context"
+	"flag"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunIdempotencyPurge deletes the expired idempotency keys, ex: from a cron
+// job.
+func RunIdempotencyPurge(cmd *flag.FlagSet, args []string) {
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithShopeefunPostgres(),
+	)
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Fatal().Err(err).Msg("Error while closing database connection")
+		}
+	}()
+
+	svc := service.NewIdempotencyService(repository.NewIdempotencyRepository(adapter.Adapters.ShopeefunPostgres))
+	deleted, err := svc.Purge(context.Background())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error while purging idempotency keys")
+	}
+
+	log.Info().Int64("deleted", deleted).Msg("Expired idempotency keys purged")
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/monitor"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -56,7 +57,10 @@ func RunServer(cmd *flag.FlagSet, args []string) {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
 		AllowMethods: "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
-		AllowHeaders: "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization",
+		AllowHeaders: "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization,X-USER-ID,Idempotency-Key",
+	}))
+	app.Use(requestid.New(requestid.Config{
+		ContextKey: "request_id",
 	}))
 	// End Application Middlewares
 
@@ -64,6 +68,7 @@ func RunServer(cmd *flag.FlagSet, args []string) {
 		adapter.WithRestServer(app),
 		adapter.WithShopeefunPostgres(),
 		adapter.WithValidator(validator.NewValidator()),
+		adapter.WithWorkers(),
 	)
 
 	infrastructure.InitializeLogger(envs.App.Environtment, envs.App.LogFile, logLevel)
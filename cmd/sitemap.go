@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/module/sitemap/entity"
+	"codebase-app/internal/module/sitemap/repository"
+	"codebase-app/internal/module/sitemap/service"
+	"context"
+	"flag"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunSitemap generates the sitemap index and files, ex: from a cron job.
+func RunSitemap(cmd *flag.FlagSet, args []string) {
+	var (
+		dir = cmd.String("dir", config.Envs.Sitemap.Dir, "directory to write the sitemap files to")
+	)
+
+	if err := cmd.Parse(args); err != nil {
+		log.Fatal().Err(err).Msg("Error while parsing flags")
+	}
+
+	adapter.Adapters.Sync(
+		adapter.WithShopeefunPostgres(),
+	)
+	defer func() {
+		if err := adapter.Adapters.Unsync(); err != nil {
+			log.Fatal().Err(err).Msg("Error while closing database connection")
+		}
+	}()
+
+	svc := service.NewSitemapService(repository.NewSitemapRepository(adapter.Adapters.ShopeefunPostgres))
+	resp, err := svc.Generate(context.Background(), &entity.GenerateRequest{
+		Dir:           *dir,
+		StorefrontUrl: config.Envs.Sitemap.StorefrontURL,
+		FilesUrl:      config.Envs.Sitemap.FilesURL,
+		UrlsPerFile:   config.Envs.Sitemap.UrlsPerFile,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Str("dir", *dir).Msg("Error while generating sitemap")
+	}
+
+	log.Info().Int("files", len(resp.Files)).Str("dir", *dir).Msg("Sitemap generated")
+}
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS product_import_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    shop_id UUID NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total_rows INT NOT NULL DEFAULT 0,
+    processed_rows INT NOT NULL DEFAULT 0,
+    success_rows INT NOT NULL DEFAULT 0,
+    failed_rows INT NOT NULL DEFAULT 0,
+    error_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+
+    FOREIGN KEY (shop_id) REFERENCES shops(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_import_jobs_user_id ON product_import_jobs (user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS product_import_errors (
+    id BIGSERIAL PRIMARY KEY,
+    job_id UUID NOT NULL,
+    row_number INT NOT NULL,
+    errors JSONB NOT NULL,
+
+    FOREIGN KEY (job_id) REFERENCES product_import_jobs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_import_errors_job_id ON product_import_errors (job_id, row_number);
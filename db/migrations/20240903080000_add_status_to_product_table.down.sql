@@ -0,0 +1,2 @@
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_status_check;
+ALTER TABLE product DROP COLUMN IF EXISTS status;
@@ -0,0 +1,2 @@
+ALTER TABLE product ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+ALTER TABLE product ADD CONSTRAINT product_status_check CHECK (status IN ('active', 'inactive'));
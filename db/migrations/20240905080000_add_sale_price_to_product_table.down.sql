@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_product_sale_ends_at;
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_sale_check;
+ALTER TABLE product
+    DROP COLUMN IF EXISTS sale_price,
+    DROP COLUMN IF EXISTS sale_starts_at,
+    DROP COLUMN IF EXISTS sale_ends_at;
@@ -0,0 +1,10 @@
+ALTER TABLE product
+    ADD COLUMN IF NOT EXISTS sale_price DECIMAL(12, 4),
+    ADD COLUMN IF NOT EXISTS sale_starts_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS sale_ends_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE product ADD CONSTRAINT product_sale_check CHECK (
+    sale_price IS NULL OR (sale_starts_at IS NOT NULL AND sale_ends_at IS NOT NULL AND sale_ends_at > sale_starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_sale_ends_at ON product (sale_ends_at) WHERE sale_price IS NOT NULL;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS campaigns (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaigns_window ON campaigns (starts_at, ends_at) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS campaign_products (
+    campaign_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    shop_id UUID NOT NULL,
+    campaign_price DECIMAL(12, 4) NOT NULL,
+    quota INT NOT NULL,
+    sold INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (campaign_id, product_id),
+    FOREIGN KEY (campaign_id) REFERENCES campaigns(id),
+    FOREIGN KEY (product_id) REFERENCES product(id),
+    FOREIGN KEY (shop_id) REFERENCES shops(id),
+    CHECK (campaign_price > 0),
+    CHECK (quota > 0),
+    CHECK (sold >= 0 AND sold <= quota)
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_products_product_id ON campaign_products (product_id);
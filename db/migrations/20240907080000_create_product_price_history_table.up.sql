@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS product_price_history (
+    id BIGSERIAL PRIMARY KEY,
+    product_id UUID NOT NULL,
+    price DECIMAL(12, 4) NOT NULL,
+    previous_price DECIMAL(12, 4),
+    changed_by VARCHAR(100),
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    FOREIGN KEY (product_id) REFERENCES product(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_price_history_product_id ON product_price_history (product_id, changed_at DESC);
+
+-- Existing products start their history with the current price. Earlier
+-- changes were overwritten and cannot be recovered.
+INSERT INTO product_price_history (product_id, price)
+SELECT id, price FROM product;
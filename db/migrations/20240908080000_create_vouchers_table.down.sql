@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS voucher_usages;
+DROP TABLE IF EXISTS voucher_categories;
+DROP TABLE IF EXISTS voucher_products;
+DROP TABLE IF EXISTS vouchers;
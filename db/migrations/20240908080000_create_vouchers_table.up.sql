@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS vouchers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    discount_type VARCHAR(20) NOT NULL,
+    discount_percent INT,
+    discount_amount DECIMAL(12, 4),
+    max_discount DECIMAL(12, 4),
+    min_spend DECIMAL(12, 4) NOT NULL DEFAULT 0,
+    usage_limit INT,
+    per_user_limit INT,
+    used_count INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    FOREIGN KEY (shop_id) REFERENCES shops(id),
+    CHECK (discount_type IN ('percentage', 'fixed')),
+    CHECK (
+        (discount_type = 'percentage' AND discount_percent BETWEEN 1 AND 100)
+        OR (discount_type = 'fixed' AND discount_amount > 0)
+    ),
+    CHECK (ends_at > starts_at),
+    CHECK (usage_limit IS NULL OR used_count <= usage_limit)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vouchers_shop_id_code ON vouchers (shop_id, UPPER(code)) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS voucher_products (
+    voucher_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+
+    PRIMARY KEY (voucher_id, product_id),
+    FOREIGN KEY (voucher_id) REFERENCES vouchers(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES product(id)
+);
+
+CREATE TABLE IF NOT EXISTS voucher_categories (
+    voucher_id UUID NOT NULL,
+    category_id UUID NOT NULL,
+
+    PRIMARY KEY (voucher_id, category_id),
+    FOREIGN KEY (voucher_id) REFERENCES vouchers(id) ON DELETE CASCADE,
+    FOREIGN KEY (category_id) REFERENCES category(id)
+);
+
+CREATE TABLE IF NOT EXISTS voucher_usages (
+    id BIGSERIAL PRIMARY KEY,
+    voucher_id UUID NOT NULL,
+    user_id VARCHAR(100) NOT NULL,
+    order_id VARCHAR(100) NOT NULL,
+    discount DECIMAL(12, 4) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    FOREIGN KEY (voucher_id) REFERENCES vouchers(id),
+    UNIQUE (voucher_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_voucher_usages_voucher_id_user_id ON voucher_usages (voucher_id, user_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS product_price_tiers (
+    product_id UUID NOT NULL,
+    min_quantity INT NOT NULL,
+    max_quantity INT,
+    price DECIMAL(12, 4) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, min_quantity),
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE,
+    CHECK (min_quantity >= 2),
+    CHECK (max_quantity IS NULL OR max_quantity >= min_quantity),
+    CHECK (price > 0)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE shops DROP COLUMN IF EXISTS currency;
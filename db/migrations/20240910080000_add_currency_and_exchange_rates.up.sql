@@ -0,0 +1,13 @@
+ALTER TABLE shops ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'IDR';
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    base_currency VARCHAR(3) NOT NULL,
+    quote_currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(24, 12) NOT NULL,
+    updated_by VARCHAR(100) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (base_currency, quote_currency),
+    CHECK (rate > 0),
+    CHECK (base_currency <> quote_currency)
+);
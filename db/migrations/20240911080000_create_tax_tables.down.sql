@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS shop_tax_settings;
+
+ALTER TABLE category DROP COLUMN IF EXISTS tax_class_id;
+
+DROP TABLE IF EXISTS tax_classes;
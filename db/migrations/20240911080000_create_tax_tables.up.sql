@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS tax_classes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    rate DECIMAL(5, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CHECK (rate >= 0 AND rate <= 100)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tax_classes_code_idx ON tax_classes (UPPER(code));
+
+INSERT INTO tax_classes (code, name, rate) VALUES
+    ('PPN', 'PPN 11%', 11),
+    ('NON_PKP', 'Non-PKP (tidak dipungut PPN)', 0)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE category ADD COLUMN IF NOT EXISTS tax_class_id UUID REFERENCES tax_classes (id);
+
+UPDATE category SET tax_class_id = (SELECT id FROM tax_classes WHERE code = 'PPN') WHERE tax_class_id IS NULL;
+
+-- shop_tax_settings overrides the category tax class for every product of
+-- the shop, e.g. NON_PKP for sellers not registered for PPN.
+CREATE TABLE IF NOT EXISTS shop_tax_settings (
+    shop_id UUID PRIMARY KEY REFERENCES shops (id),
+    tax_class_id UUID REFERENCES tax_classes (id),
+    prices_include_tax BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS product_specifications (
+    product_id UUID NOT NULL REFERENCES product (id) ON DELETE CASCADE,
+    key VARCHAR(100) NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    value_type VARCHAR(10) NOT NULL,
+    value_text VARCHAR(255),
+    value_number DECIMAL(20, 6),
+    value_boolean BOOLEAN,
+    unit VARCHAR(20) NOT NULL DEFAULT '',
+    -- value_normalized is the lower-cased value with its unit, e.g. "8gb",
+    -- and is what attribute filters compare against.
+    value_normalized VARCHAR(300) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (product_id, key),
+    CHECK (value_type IN ('text', 'number', 'boolean')),
+    CHECK (
+        (value_type = 'text' AND value_text IS NOT NULL) OR
+        (value_type = 'number' AND value_number IS NOT NULL) OR
+        (value_type = 'boolean' AND value_boolean IS NOT NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS product_specifications_filter_idx ON product_specifications (key, value_normalized, product_id);
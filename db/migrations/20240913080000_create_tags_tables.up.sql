@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(50) NOT NULL UNIQUE,
+    -- merged_into points to the tag that replaced this one; assigning the old
+    -- name assigns the replacement instead.
+    merged_into UUID,
+    banned_at TIMESTAMP WITH TIME ZONE,
+    banned_by VARCHAR(100),
+    ban_reason VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    FOREIGN KEY (merged_into) REFERENCES tags(id)
+);
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id UUID NOT NULL,
+    tag_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, tag_id),
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id)
+);
+
+CREATE INDEX IF NOT EXISTS product_tags_tag_id_idx ON product_tags (tag_id, product_id);
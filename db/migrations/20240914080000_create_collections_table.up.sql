@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    CHECK (status IN ('draft', 'published')),
+    FOREIGN KEY (shop_id) REFERENCES shops(id)
+);
+
+CREATE INDEX IF NOT EXISTS collections_shop_id_idx ON collections (shop_id, status) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS collection_products (
+    collection_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    position INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (collection_id, product_id),
+    FOREIGN KEY (collection_id) REFERENCES collections(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS collection_products_position_idx ON collection_products (collection_id, position);
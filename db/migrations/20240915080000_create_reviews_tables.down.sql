@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_rating_stats;
+DROP TABLE IF EXISTS reviews;
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    rating SMALLINT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    photo_urls TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'published',
+    moderation_reason VARCHAR(255),
+    moderated_by VARCHAR(100),
+    moderated_at TIMESTAMP WITH TIME ZONE,
+    seller_reply TEXT,
+    seller_replied_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    CHECK (rating BETWEEN 1 AND 5),
+    CHECK (status IN ('published', 'pending', 'hidden')),
+    FOREIGN KEY (product_id) REFERENCES product(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS reviews_product_user_idx ON reviews (product_id, user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS reviews_product_listing_idx ON reviews (product_id, status, created_at) WHERE deleted_at IS NULL;
+
+-- product_rating_stats holds the aggregates of the published reviews of a
+-- product. It is updated in the same transaction as the review so product
+-- listings never have to aggregate reviews.
+CREATE TABLE IF NOT EXISTS product_rating_stats (
+    product_id UUID PRIMARY KEY,
+    rating_count INT NOT NULL DEFAULT 0,
+    rating_sum INT NOT NULL DEFAULT 0,
+    rating_1 INT NOT NULL DEFAULT 0,
+    rating_2 INT NOT NULL DEFAULT 0,
+    rating_3 INT NOT NULL DEFAULT 0,
+    rating_4 INT NOT NULL DEFAULT 0,
+    rating_5 INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS product_question_votes;
+DROP TABLE IF EXISTS product_answers;
+DROP TABLE IF EXISTS product_questions;
+DROP TABLE IF EXISTS notifications;
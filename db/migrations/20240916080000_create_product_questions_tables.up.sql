@@ -0,0 +1,53 @@
+-- notifications is the in-app inbox of a user.
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    data JSONB NOT NULL DEFAULT '{}',
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_idx ON notifications (user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS product_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    body TEXT NOT NULL,
+    upvote_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    FOREIGN KEY (product_id) REFERENCES product(id)
+);
+
+CREATE INDEX IF NOT EXISTS product_questions_product_idx ON product_questions (product_id, created_at) WHERE deleted_at IS NULL;
+
+-- is_official marks answers written by the owner of the product's shop.
+CREATE TABLE IF NOT EXISTS product_answers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    question_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    body TEXT NOT NULL,
+    is_official BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    FOREIGN KEY (question_id) REFERENCES product_questions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS product_answers_question_idx ON product_answers (question_id, created_at) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS product_question_votes (
+    question_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (question_id, user_id),
+    FOREIGN KEY (question_id) REFERENCES product_questions(id) ON DELETE CASCADE
+);
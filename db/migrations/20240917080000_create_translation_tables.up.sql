@@ -0,0 +1,25 @@
+-- Translations of product and category text. The columns on product and
+-- category stay the original content, in APP_DEFAULT_LOCALE. locale is a
+-- lower case language tag, ex: "en" or "en-us".
+CREATE TABLE IF NOT EXISTS product_translations (
+    product_id UUID NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (product_id, locale),
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS category_translations (
+    category_id UUID NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (category_id, locale),
+    FOREIGN KEY (category_id) REFERENCES category(id) ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+ALTER TABLE product_translations
+    DROP COLUMN IF EXISTS description_excerpt,
+    DROP COLUMN IF EXISTS description_html,
+    DROP COLUMN IF EXISTS description_format;
+
+ALTER TABLE product
+    DROP COLUMN IF EXISTS description_excerpt,
+    DROP COLUMN IF EXISTS description_html,
+    DROP COLUMN IF EXISTS description_format;
@@ -0,0 +1,27 @@
+-- description keeps what the seller wrote in description_format.
+-- description_html is the sanitized rendering shown on the storefront and
+-- description_excerpt its plain text for listing cards.
+ALTER TABLE product
+    ADD COLUMN IF NOT EXISTS description_format VARCHAR(10) NOT NULL DEFAULT 'plain',
+    ADD COLUMN IF NOT EXISTS description_html TEXT,
+    ADD COLUMN IF NOT EXISTS description_excerpt VARCHAR(200);
+
+ALTER TABLE product_translations
+    ADD COLUMN IF NOT EXISTS description_format VARCHAR(10) NOT NULL DEFAULT 'plain',
+    ADD COLUMN IF NOT EXISTS description_html TEXT,
+    ADD COLUMN IF NOT EXISTS description_excerpt VARCHAR(200);
+
+-- existing descriptions are treated as plain text: escaped, with line breaks
+UPDATE product
+SET
+    description_html = '<p>' || replace(replace(replace(replace(replace(description,
+        '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), E'\n', '<br>') || '</p>',
+    description_excerpt = left(regexp_replace(trim(description), '\s+', ' ', 'g'), 200)
+WHERE description IS NOT NULL AND description <> '';
+
+UPDATE product_translations
+SET
+    description_html = '<p>' || replace(replace(replace(replace(replace(description,
+        '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), E'\n', '<br>') || '</p>',
+    description_excerpt = left(regexp_replace(trim(description), '\s+', ' ', 'g'), 200)
+WHERE description IS NOT NULL AND description <> '';
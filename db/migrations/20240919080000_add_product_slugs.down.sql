@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS product_slug_redirects;
+
+DROP INDEX IF EXISTS product_shop_slug_idx;
+
+ALTER TABLE product DROP COLUMN IF EXISTS slug;
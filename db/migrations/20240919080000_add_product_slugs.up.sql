@@ -0,0 +1,43 @@
+-- slug is the SEO friendly name of a product in storefront URLs, unique
+-- among the live products of its shop.
+ALTER TABLE product ADD COLUMN IF NOT EXISTS slug VARCHAR(120);
+
+-- existing products get a slug of their name, suffixed with -2, -3, ... in
+-- creation order when a shop has several products of the same name
+WITH slugs AS (
+    SELECT
+        id,
+        shop_id,
+        created_at,
+        COALESCE(NULLIF(trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), ''), 'produk') as base
+    FROM product
+), numbered AS (
+    SELECT
+        id,
+        base,
+        ROW_NUMBER() OVER (PARTITION BY shop_id, base ORDER BY created_at, id) as n
+    FROM slugs
+)
+UPDATE product p
+SET slug = CASE WHEN nb.n = 1 THEN nb.base ELSE nb.base || '-' || nb.n END
+FROM numbered nb
+WHERE nb.id = p.id;
+
+ALTER TABLE product ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS product_shop_slug_idx ON product (shop_id, slug) WHERE deleted_at IS NULL;
+
+-- A renamed product keeps its previous slugs so old links are redirected to
+-- the current one. Another product of the shop can't take them.
+CREATE TABLE IF NOT EXISTS product_slug_redirects (
+    shop_id UUID NOT NULL,
+    slug VARCHAR(120) NOT NULL,
+    product_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (shop_id, slug),
+    FOREIGN KEY (shop_id) REFERENCES shops(id),
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS product_slug_redirects_product_id_idx ON product_slug_redirects (product_id);
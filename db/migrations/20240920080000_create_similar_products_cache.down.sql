@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS product_similar_cache;
+
+DROP INDEX IF EXISTS product_name_trgm_idx;
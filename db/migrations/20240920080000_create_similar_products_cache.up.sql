@@ -0,0 +1,18 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- finds products with a similar name, see the % operator
+CREATE INDEX IF NOT EXISTS product_name_trgm_idx ON product USING GIN (name gin_trgm_ops);
+
+-- Ranking of the similar products of a product, with or without the
+-- products of its own shop. Only ids and scores are kept so prices and stock
+-- are always read fresh.
+CREATE TABLE IF NOT EXISTS product_similar_cache (
+    product_id UUID NOT NULL,
+    exclude_same_shop BOOLEAN NOT NULL,
+    items JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (product_id, exclude_same_shop),
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS product_duplicate_of_idx;
+
+ALTER TABLE product
+    DROP COLUMN IF EXISTS duplicate_flagged_at,
+    DROP COLUMN IF EXISTS duplicate_score,
+    DROP COLUMN IF EXISTS duplicate_of;
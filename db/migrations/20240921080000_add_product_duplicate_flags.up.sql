@@ -0,0 +1,8 @@
+-- A product flagged as a suspected duplicate points to the product of the
+-- same shop it is most alike, see PRODUCT_DUPLICATE_POLICY.
+ALTER TABLE product
+    ADD COLUMN IF NOT EXISTS duplicate_of UUID REFERENCES product(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS duplicate_score REAL,
+    ADD COLUMN IF NOT EXISTS duplicate_flagged_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS product_duplicate_of_idx ON product (duplicate_of) WHERE duplicate_of IS NOT NULL;
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS product_moderations;
+
+DROP TABLE IF EXISTS moderation_rules;
+
+DROP INDEX IF EXISTS product_moderation_queue_idx;
+
+UPDATE product SET status = 'inactive' WHERE status IN ('flagged', 'rejected');
+ALTER TABLE product
+    DROP COLUMN IF EXISTS moderation_flagged_at,
+    DROP COLUMN IF EXISTS moderation_reasons,
+    DROP COLUMN IF EXISTS moderation_previous_status;
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_status_check;
+ALTER TABLE product ADD CONSTRAINT product_status_check CHECK (status IN ('active', 'inactive'));
@@ -0,0 +1,46 @@
+-- Flagged products wait in the review queue, rejected ones were refused.
+-- Both are hidden like inactive products. moderation_reasons are the banned
+-- keywords the screening matched and moderation_previous_status the status
+-- an approval restores.
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_status_check;
+ALTER TABLE product ADD CONSTRAINT product_status_check CHECK (status IN ('active', 'inactive', 'flagged', 'rejected'));
+ALTER TABLE product
+    ADD COLUMN IF NOT EXISTS moderation_previous_status VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS moderation_reasons TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS moderation_flagged_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS product_moderation_queue_idx ON product (moderation_flagged_at) WHERE status = 'flagged' AND deleted_at IS NULL;
+
+-- Banned keywords and regular expressions screened in product names and
+-- descriptions. A rule without category applies to every category.
+CREATE TABLE IF NOT EXISTS moderation_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    category_id UUID,
+    kind VARCHAR(10) NOT NULL,
+    pattern VARCHAR(200) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+
+    CONSTRAINT moderation_rules_kind_check CHECK (kind IN ('keyword', 'regex')),
+    FOREIGN KEY (category_id) REFERENCES category(id)
+);
+
+CREATE INDEX IF NOT EXISTS moderation_rules_category_id_idx ON moderation_rules (category_id) WHERE deleted_at IS NULL;
+
+-- The decisions taken in the review queue.
+CREATE TABLE IF NOT EXISTS product_moderations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    note TEXT,
+    reviewed_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT product_moderations_decision_check CHECK (decision IN ('approved', 'rejected')),
+    FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS product_moderations_product_id_idx ON product_moderations (product_id, created_at);
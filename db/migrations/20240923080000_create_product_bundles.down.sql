@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS product_bundle_items;
+
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_type_check;
+ALTER TABLE product DROP COLUMN IF EXISTS type;
@@ -0,0 +1,18 @@
+-- A bundle is sold as one product but holds no stock of its own: its stock
+-- is how many complete sets its components can make, and selling it takes
+-- stock from every component.
+ALTER TABLE product ADD COLUMN IF NOT EXISTS type VARCHAR(10) NOT NULL DEFAULT 'single';
+ALTER TABLE product ADD CONSTRAINT product_type_check CHECK (type IN ('single', 'bundle'));
+
+CREATE TABLE IF NOT EXISTS product_bundle_items (
+    bundle_id UUID NOT NULL REFERENCES product (id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES product (id),
+    quantity INT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (bundle_id, product_id),
+    CHECK (quantity > 0),
+    CHECK (bundle_id <> product_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_bundle_items_product_id_idx ON product_bundle_items (product_id);
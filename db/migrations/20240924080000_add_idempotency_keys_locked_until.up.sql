@@ -0,0 +1,4 @@
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE;
+
+-- claims left by a crash before this migration can be taken over right away
+UPDATE idempotency_keys SET locked_until = created_at WHERE completed_at IS NULL;
@@ -2,8 +2,10 @@ package seeds
 
 import (
 	"codebase-app/internal/adapter"
+	"codebase-app/pkg"
 	"context"
 	"os"
+	"strconv"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/jmoiron/sqlx"
@@ -357,6 +359,7 @@ func (s *Seed) productsSeed(total int) {
 		categories = make([]generalData, 0)
 		productMaps = make([]map[string]any, 0)
 		shops = make([]generalData, 0)
+		slugs = make(map[string]int) // products per shop and slug
 	)
 
 	err = s.db.Select(&categories, `SELECT id FROM category`)
@@ -375,9 +378,17 @@ func (s *Seed) productsSeed(total int) {
 		selectedCategory := categories[gofakeit.Number(0, len(categories)-1)]
 		selectedShop := shops[gofakeit.Number(0, len(shops)-1)]
 
+		name := gofakeit.ProductName()
+		slug := pkg.Slugify(name)
+		slugs[selectedShop.Id+"/"+slug]++
+		if n := slugs[selectedShop.Id+"/"+slug]; n > 1 {
+			slug += "-" + strconv.Itoa(n)
+		}
+
 		dataProductToInsert := make(map[string]any)
 		dataProductToInsert["category_id"] = selectedCategory.Id
-		dataProductToInsert["name"] = gofakeit.ProductName()
+		dataProductToInsert["name"] = name
+		dataProductToInsert["slug"] = slug
 		dataProductToInsert["brand"] = gofakeit.ProductName()
 		dataProductToInsert["price"] = gofakeit.Price(10000, 500000)
 		dataProductToInsert["stock"] = gofakeit.Number(10, 500)
@@ -387,8 +398,8 @@ func (s *Seed) productsSeed(total int) {
 	}
 
 	_, err = tx.NamedExec(`
-		INSERT INTO product (category_id, name, brand, price, stock, shop_id)
-		VALUES (:category_id, :name, :brand, :price, :stock, :shop_id)	
+		INSERT INTO product (category_id, name, slug, brand, price, stock, shop_id)
+		VALUES (:category_id, :name, :slug, :brand, :price, :stock, :shop_id)	
 	`, productMaps)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating products")
@@ -32,6 +32,7 @@ type Adapter struct {
 	ShopeefunPostgres *sqlx.DB
 	Validator         Validator // *validator.Validator
 	ShopeefunStorage  *s3.Client
+	Workers           *Workers
 }
 
 func (a *Adapter) Sync(opts ...Option) {
@@ -58,6 +59,12 @@ func (a *Adapter) Unsync() error {
 		log.Info().Msg("Ws server disconnected")
 	}
 
+	// jobs still use the database until they stop
+	if a.Workers != nil {
+		a.Workers.Stop()
+		log.Info().Msg("Workers stopped")
+	}
+
 	if a.ShopeefunPostgres != nil {
 		if err := a.ShopeefunPostgres.Close(); err != nil {
 			errs = append(errs, err.Error())
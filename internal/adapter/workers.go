@@ -0,0 +1,85 @@
+package adapter
+
+import (
+	"codebase-app/internal/infrastructure/config"
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Workers runs background jobs on a fixed number of goroutines. Their
+// context is cancelled by Unsync, which waits for the running jobs to stop.
+type Workers struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   chan func(ctx context.Context)
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func WithWorkers() Option {
+	return func(a *Adapter) {
+		size := max(config.Envs.Workers.Size, 1)
+
+		w := &Workers{jobs: make(chan func(ctx context.Context), max(config.Envs.Workers.Queue, 0))}
+		w.ctx, w.cancel = context.WithCancel(context.Background())
+
+		for i := 0; i < size; i++ {
+			w.wg.Add(1)
+			go w.run()
+		}
+
+		a.Workers = w
+		log.Info().Int("size", size).Msg("Workers started")
+	}
+}
+
+func (w *Workers) run() {
+	defer w.wg.Done()
+
+	for job := range w.jobs {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Error().Any("panic", r).Msg("adapter::Workers - Job panicked")
+				}
+			}()
+			job(w.ctx)
+		}()
+	}
+}
+
+// Submit queues job and returns false when the queue is full or the workers
+// are stopped. Jobs still queued at shutdown run with a cancelled context,
+// so they can record that they did not finish.
+func (w *Workers) Submit(job func(ctx context.Context)) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return false
+	}
+
+	select {
+	case w.jobs <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+// Stop cancels the running jobs and waits for every worker to return.
+func (w *Workers) Stop() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.jobs)
+	}
+	w.mu.Unlock()
+
+	w.cancel()
+	w.wg.Wait()
+}
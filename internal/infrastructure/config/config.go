@@ -24,6 +24,7 @@ type Config struct {
 		LogFileWs               string `env:"APP_LOG_FILE_WS" env-default:"./logs/ws.log"`
 		LocalStoragePublicPath  string `env:"LOCAL_STORAGE_PUBLIC_PATH" env-default:"./storage/public"`
 		LocalStoragePrivatePath string `env:"LOCAL_STORAGE_PRIVATE_PATH" env-default:"./storage/private"`
+		DefaultLocale           string `env:"APP_DEFAULT_LOCALE" env-default:"id" env-description:"language product and category content is written in"`
 	}
 	DB struct {
 		ConnectionTimeout int `env:"DB_CONN_TIMEOUT" env-default:"30" env-description:"database timeout in seconds"`
@@ -31,6 +32,23 @@ type Config struct {
 		MaxIdleCons       int `env:"DB_MAX_IdLE_CONS" env-default:"20" env-description:"database max idle conn in seconds"`
 		ConnMaxLifetime   int `env:"DB_CONN_MAX_LIFETIME" env-default:"0" env-description:"database conn max lifetime in seconds"`
 	}
+	Product struct {
+		DuplicatePolicy string `env:"PRODUCT_DUPLICATE_POLICY" env-default:"flag" env-description:"what happens to a near duplicate of a product of the same shop: reject, flag or off"`
+	}
+	Sitemap struct {
+		Dir           string `env:"SITEMAP_DIR" env-default:"./storage/public/sitemaps" env-description:"directory the sitemap files are written to"`
+		StorefrontURL string `env:"SITEMAP_STOREFRONT_URL" env-default:"http://localhost:3000" env-description:"storefront URL the sitemap pages are listed under"`
+		FilesURL      string `env:"SITEMAP_FILES_URL" env-default:"http://localhost:3000/products/sitemaps" env-description:"public URL the sitemap files are served from"`
+		UrlsPerFile   int    `env:"SITEMAP_URLS_PER_FILE" env-default:"50000" env-description:"most URLs in one sitemap file"`
+	}
+	Workers struct {
+		Size  int `env:"WORKERS_SIZE" env-default:"4" env-description:"number of background jobs, like product imports, run at a time"`
+		Queue int `env:"WORKERS_QUEUE" env-default:"100" env-description:"number of background jobs waiting for a worker before new ones are refused"`
+	}
+	Idempotency struct {
+		KeyTTL  int `env:"IDEMPOTENCY_KEY_TTL" env-default:"86400" env-description:"how long an idempotency key is remembered in seconds"`
+		LockTTL int `env:"IDEMPOTENCY_LOCK_TTL" env-default:"60" env-description:"how long a request holds its idempotency key before a retry can take over, in seconds"`
+	}
 	Guard struct {
 		JwtPrivateKey   string `env:"JWT_PRIVATE_KEY"`
 		JwtPrivateKeyWs string `env:"JWT_PRIVATE_KEY_WS"`
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/module/idempotency/entity"
+	"codebase-app/internal/module/idempotency/repository"
+	"codebase-app/internal/module/idempotency/service"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// IdempotencyKey makes a POST endpoint safe to retry. The first response for
+// an Idempotency-Key is stored per user and replayed for identical retries
+// until the key expires (config IDEMPOTENCY_KEY_TTL). Reusing a key with a
+// different payload is rejected. It must run after UserIdHeader.
+//
+// Server errors (5xx) are not remembered so the client can retry them. A
+// request that never answered, ex: the process crashed, holds the key for
+// IDEMPOTENCY_LOCK_TTL only.
+func IdempotencyKey(c *fiber.Ctx) error {
+	var (
+		key    = c.Get(HeaderIdempotencyKey)
+		userId = GetLocals(c).UserId
+		svc    = service.NewIdempotencyService(repository.NewIdempotencyRepository(adapter.Adapters.ShopeefunPostgres))
+		ctx    = c.Context()
+	)
+
+	if key == "" {
+		return c.Next()
+	}
+
+	if len(key) > 255 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": "Idempotency-Key maksimal 255 karakter",
+			"success": false,
+		})
+	}
+
+	sum := sha256.Sum256([]byte(c.Method() + " " + c.Path() + "\n" + string(c.Body())))
+
+	claim, err := svc.Claim(ctx, &entity.ClaimRequest{
+		UserId:      userId,
+		Key:         key,
+		RequestHash: hex.EncodeToString(sum[:]),
+		TTL:         time.Duration(config.Envs.Idempotency.KeyTTL) * time.Second,
+		Lease:       time.Duration(config.Envs.Idempotency.LockTTL) * time.Second,
+	})
+	switch {
+	case errors.Is(err, entity.ErrKeyReused):
+		log.Warn().Str("key", key).Str("user_id", userId).Msg("middleware::IdempotencyKey - Key reused with a different payload")
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"message": "Idempotency-Key sudah digunakan untuk permintaan yang berbeda",
+			"success": false,
+		})
+	case errors.Is(err, entity.ErrInProgress):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"message": "Permintaan dengan Idempotency-Key ini masih diproses",
+			"success": false,
+		})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Permintaan anda gagal diproses",
+			"success": false,
+		})
+	}
+
+	if claim.Response != nil {
+		if claim.Response.ContentType != "" {
+			c.Set(fiber.HeaderContentType, claim.Response.ContentType)
+		}
+		c.Set("Idempotent-Replayed", "true")
+
+		return c.Status(*claim.Response.StatusCode).Send(claim.Response.Body)
+	}
+
+	if err := c.Next(); err != nil {
+		_ = svc.Release(ctx, claim.Id)
+		return err
+	}
+
+	status := c.Response().StatusCode()
+	if status >= fiber.StatusInternalServerError {
+		_ = svc.Release(ctx, claim.Id)
+		return nil
+	}
+
+	// a failure leaves the claim to expire with its lease, so a retry runs
+	// the request again
+	_ = svc.Complete(ctx, &entity.CompleteRequest{
+		Id:          claim.Id,
+		StatusCode:  status,
+		Body:        c.Response().Body(),
+		ContentType: string(c.Response().Header.ContentType()),
+	})
+
+	return nil
+}
@@ -6,8 +6,9 @@ import (
 )
 
 type Locals struct {
-	UserId string
-	Role   string
+	UserId    string
+	Role      string
+	RequestId string
 }
 
 func GetLocals(c *fiber.Ctx) *Locals {
@@ -26,6 +27,11 @@ func GetLocals(c *fiber.Ctx) *Locals {
 		log.Warn().Msg("middleware::Locals-GetLocals failed to get role from locals")
 	}
 
+	requestId, ok := c.Locals("request_id").(string)
+	if ok {
+		l.RequestId = requestId
+	}
+
 	return &l
 }
 
@@ -36,3 +42,7 @@ func (l *Locals) GetUserId() string {
 func (l *Locals) GetRole() string {
 	return l.Role
 }
+
+func (l *Locals) GetRequestId() string {
+	return l.RequestId
+}
@@ -20,4 +20,14 @@ func UserIdHeader(c *fiber.Ctx) error {
 	c.Locals("user_id", userId)
 
 	return c.Next()
-}
\ No newline at end of file
+}
+
+// OptionalUserIdHeader sets the user id when the header is present, for the
+// public routes that show the shop owner more than anyone else.
+func OptionalUserIdHeader(c *fiber.Ctx) error {
+	if userId := c.Get("X-USER-ID"); userId != "" {
+		c.Locals("user_id", userId)
+	}
+
+	return c.Next()
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"encoding/json"
+	"time"
+)
+
+const (
+	EntityShop     = "shop"
+	EntityProduct  = "product"
+	EntityCampaign = "campaign"
+
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// RecordRequest is what the shop and product services hand to the audit
+// service after a successful mutation. Before and After can be any value
+// that marshals to a JSON object; the diff is computed from them.
+type RecordRequest struct {
+	ActorId   string
+	RequestId string
+
+	Entity   string
+	EntityId string
+	Action   string
+	Before   any
+	After    any
+}
+
+type CreateAuditLogRequest struct {
+	ActorId   string          `db:"actor_id"`
+	RequestId string          `db:"request_id"`
+	Entity    string          `db:"entity"`
+	EntityId  string          `db:"entity_id"`
+	Action    string          `db:"action"`
+	Before    json.RawMessage `db:"before"`
+	After     json.RawMessage `db:"after"`
+	Diff      json.RawMessage `db:"diff"`
+}
+
+type FieldDiff struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type GetAuditLogsRequest struct {
+	Entity   string `query:"entity" validate:"omitempty,oneof=shop product"`
+	EntityId string `query:"entity_id"`
+	ActorId  string `query:"actor_id"`
+	Action   string `query:"action" validate:"omitempty,oneof=create update delete"`
+	From     string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To       string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+
+	Page     int `query:"page" validate:"required"`
+	Paginate int `query:"paginate" validate:"required"`
+}
+
+func (r *GetAuditLogsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type AuditLogItem struct {
+	Id        string          `json:"id" db:"id"`
+	ActorId   string          `json:"actor_id" db:"actor_id"`
+	RequestId string          `json:"request_id" db:"request_id"`
+	Entity    string          `json:"entity" db:"entity"`
+	EntityId  string          `json:"entity_id" db:"entity_id"`
+	Action    string          `json:"action" db:"action"`
+	Before    json.RawMessage `json:"before" db:"before"`
+	After     json.RawMessage `json:"after" db:"after"`
+	Diff      json.RawMessage `json:"diff" db:"diff"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+type GetAuditLogsResponse struct {
+	Items []AuditLogItem `json:"items"`
+	Meta  types.Meta     `json:"meta"`
+}
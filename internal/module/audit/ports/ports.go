@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"codebase-app/internal/module/audit/entity"
+	"context"
+)
+
+type AuditRepository interface {
+	CreateAuditLog(ctx context.Context, req *entity.CreateAuditLogRequest) error
+	GetAuditLogs(ctx context.Context, req *entity.GetAuditLogsRequest) (*entity.GetAuditLogsResponse, error)
+}
+
+type AuditService interface {
+	Record(ctx context.Context, req *entity.RecordRequest)
+	GetAuditLogs(ctx context.Context, req *entity.GetAuditLogsRequest) (*entity.GetAuditLogsResponse, error)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"codebase-app/internal/module/audit/entity"
+	"codebase-app/internal/module/audit/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.AuditRepository = &auditRepository{}
+
+type auditRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) *auditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+func (r *auditRepository) CreateAuditLog(ctx context.Context, req *entity.CreateAuditLogRequest) error {
+	query := `
+		INSERT INTO audit_logs (actor_id, request_id, entity, entity_id, action, before, after, diff)
+		VALUES (?, NULLIF(?, ''), ?, ?, ?, NULLIF(?, '')::jsonb, NULLIF(?, '')::jsonb, ?::jsonb)
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		req.ActorId,
+		req.RequestId,
+		req.Entity,
+		req.EntityId,
+		req.Action,
+		string(req.Before),
+		string(req.After),
+		string(req.Diff),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateAuditLog - Failed to create audit log")
+		return err
+	}
+
+	return nil
+}
+
+func (r *auditRepository) GetAuditLogs(ctx context.Context, req *entity.GetAuditLogsRequest) (*entity.GetAuditLogsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.AuditLogItem
+	}
+
+	var (
+		resp  = new(entity.GetAuditLogsResponse)
+		data  = make([]dao, 0, req.Paginate)
+		query = `
+			SELECT
+				COUNT(id) OVER() as total_data,
+				id,
+				actor_id,
+				COALESCE(request_id, '') as request_id,
+				entity,
+				entity_id,
+				action,
+				COALESCE(before, 'null'::jsonb) as before,
+				COALESCE(after, 'null'::jsonb) as after,
+				diff,
+				created_at
+			FROM audit_logs
+			WHERE 1 = 1
+		`
+		args []any
+	)
+	resp.Items = make([]entity.AuditLogItem, 0, req.Paginate)
+
+	if req.Entity != "" {
+		query += " AND entity = ?"
+		args = append(args, req.Entity)
+	}
+	if req.EntityId != "" {
+		query += " AND entity_id = ?"
+		args = append(args, req.EntityId)
+	}
+	if req.ActorId != "" {
+		query += " AND actor_id = ?"
+		args = append(args, req.ActorId)
+	}
+	if req.Action != "" {
+		query += " AND action = ?"
+		args = append(args, req.Action)
+	}
+	if req.From != "" {
+		query += " AND created_at >= ?"
+		args = append(args, req.From)
+	}
+	if req.To != "" {
+		query += " AND created_at < ?"
+		args = append(args, req.To)
+	}
+
+	query += " ORDER BY created_at DESC LIMIT ? OFFSET ?"
+	args = append(args, req.Paginate, (req.Page-1)*req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetAuditLogs - Failed to get audit logs")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.AuditLogItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"codebase-app/internal/module/audit/entity"
+	"codebase-app/internal/module/audit/ports"
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.AuditService = &auditService{}
+
+type auditService struct {
+	repo ports.AuditRepository
+}
+
+func NewAuditService(repo ports.AuditRepository) *auditService {
+	return &auditService{
+		repo: repo,
+	}
+}
+
+// Record stores an audit entry for a catalog mutation. Failing to write the
+// audit log is logged but never fails the mutation that triggered it.
+func (s *auditService) Record(ctx context.Context, req *entity.RecordRequest) {
+	var (
+		before, after map[string]any
+		log           = log.With().Str("entity", req.Entity).Str("entity_id", req.EntityId).Logger()
+	)
+
+	if req.ActorId == "" {
+		req.ActorId, _ = ctx.Value("user_id").(string)
+	}
+	if req.RequestId == "" {
+		req.RequestId, _ = ctx.Value("request_id").(string)
+	}
+
+	beforeJson, err := toJsonObject(req.Before, &before)
+	if err != nil {
+		log.Error().Err(err).Msg("service::Record - Failed to marshal before state")
+		return
+	}
+
+	afterJson, err := toJsonObject(req.After, &after)
+	if err != nil {
+		log.Error().Err(err).Msg("service::Record - Failed to marshal after state")
+		return
+	}
+
+	diff, err := json.Marshal(Diff(before, after))
+	if err != nil {
+		log.Error().Err(err).Msg("service::Record - Failed to marshal diff")
+		return
+	}
+
+	err = s.repo.CreateAuditLog(ctx, &entity.CreateAuditLogRequest{
+		ActorId:   req.ActorId,
+		RequestId: req.RequestId,
+		Entity:    req.Entity,
+		EntityId:  req.EntityId,
+		Action:    req.Action,
+		Before:    beforeJson,
+		After:     afterJson,
+		Diff:      diff,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("service::Record - Failed to store audit log")
+	}
+}
+
+func (s *auditService) GetAuditLogs(ctx context.Context, req *entity.GetAuditLogsRequest) (*entity.GetAuditLogsResponse, error) {
+	return s.repo.GetAuditLogs(ctx, req)
+}
+
+// Diff returns the top level fields whose values differ between before and
+// after. A nil map on either side means the entity did not exist.
+func Diff(before, after map[string]any) map[string]entity.FieldDiff {
+	diff := make(map[string]entity.FieldDiff)
+
+	for key, b := range before {
+		a, ok := after[key]
+		if !ok || !reflect.DeepEqual(a, b) {
+			diff[key] = entity.FieldDiff{Before: b, After: a}
+		}
+	}
+
+	for key, a := range after {
+		if _, ok := before[key]; !ok {
+			diff[key] = entity.FieldDiff{Before: nil, After: a}
+		}
+	}
+
+	return diff
+}
+
+func toJsonObject(v any, out *map[string]any) (json.RawMessage, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrQuotaExhausted is returned when the campaign is not running or the
+	// remaining quota is smaller than the requested quantity.
+	ErrQuotaExhausted = errors.New("campaign quota exhausted or campaign not active")
+	// ErrProductUnavailable is returned when the product was deleted or is
+	// not active, ex: deactivated or held for moderation.
+	ErrProductUnavailable = errors.New("product not available")
+)
+
+type CreateCampaignRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Name        string    `json:"name" validate:"required,min=3,max=255" db:"name"`
+	Description string    `json:"description" db:"description"`
+	StartsAt    time.Time `json:"starts_at" validate:"required" db:"starts_at"`
+	EndsAt      time.Time `json:"ends_at" validate:"required,gtfield=StartsAt" db:"ends_at"`
+}
+
+type CreateCampaignResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type DeleteCampaignRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Id string `params:"id" validate:"uuid"`
+}
+
+type GetCampaignRequest struct {
+	Id string `params:"id" validate:"uuid"`
+}
+
+type CampaignItem struct {
+	Id          string    `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	StartsAt    time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt      time.Time `json:"ends_at" db:"ends_at"`
+}
+
+type GetCampaignsRequest struct {
+	// State filters on the campaign window: active, upcoming or ended.
+	State string `query:"state" validate:"omitempty,oneof=active upcoming ended"`
+
+	Page     int `query:"page" validate:"required"`
+	Paginate int `query:"paginate" validate:"required"`
+}
+
+func (r *GetCampaignsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type GetCampaignsResponse struct {
+	Items []CampaignItem `json:"items"`
+	Meta  types.Meta     `json:"meta"`
+}
+
+type EnrollProductRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	CampaignId    string      `params:"id" validate:"uuid" db:"campaign_id"`
+	ProductId     string      `json:"product_id" validate:"required,uuid" db:"product_id"`
+	CampaignPrice types.Money `json:"campaign_price" validate:"required,gt=0" db:"campaign_price"`
+	Quota         int         `json:"quota" validate:"required,min=1" db:"quota"`
+
+	ShopId string `json:"-" db:"shop_id"`
+}
+
+type EnrollProductResponse struct {
+	CampaignId string `json:"campaign_id" db:"campaign_id"`
+	ProductId  string `json:"product_id" db:"product_id"`
+}
+
+type UnenrollProductRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	CampaignId string `params:"id" validate:"uuid"`
+	ProductId  string `params:"product_id" validate:"uuid"`
+}
+
+// ProductOwnership holds what enrollment needs to know about a product. The
+// price is in the shop currency.
+type ProductOwnership struct {
+	Id       string      `db:"id"`
+	ShopId   string      `db:"shop_id"`
+	UserId   string      `db:"user_id"`
+	Price    types.Money `db:"price"`
+	Currency string      `db:"currency"`
+	Stock    int         `db:"stock"`
+}
+
+type GetCampaignProductsRequest struct {
+	CampaignId string `params:"id" validate:"uuid"`
+
+	Page     int `query:"page" validate:"required"`
+	Paginate int `query:"paginate" validate:"required"`
+}
+
+func (r *GetCampaignProductsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type CampaignProductItem struct {
+	ProductId      string      `json:"product_id" db:"product_id"`
+	Name           string      `json:"name" db:"name"`
+	ShopId         string      `json:"shop_id" db:"shop_id"`
+	ImageUrl       string      `json:"image_url" db:"image_url"`
+	Price          types.Money `json:"price" db:"price"`
+	CampaignPrice  types.Money `json:"campaign_price" db:"campaign_price"`
+	Currency       string      `json:"currency" db:"currency"`
+	Quota          int         `json:"quota" db:"quota"`
+	QuotaRemaining int         `json:"quota_remaining" db:"quota_remaining"`
+}
+
+type GetCampaignProductsResponse struct {
+	Items []CampaignProductItem `json:"items"`
+	Meta  types.Meta            `json:"meta"`
+}
+
+// PurchaseRequest is sent by the checkout service when a buyer pays for a
+// flash sale item.
+type PurchaseRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	CampaignId string `params:"id" validate:"uuid"`
+	ProductId  string `params:"product_id" validate:"uuid"`
+	Quantity   int    `json:"quantity" validate:"required,min=1"`
+}
+
+type PurchaseResponse struct {
+	CampaignId     string      `json:"campaign_id"`
+	ProductId      string      `json:"product_id"`
+	Quantity       int         `json:"quantity"`
+	UnitPrice      types.Money `json:"unit_price" db:"campaign_price"`
+	TotalPrice     types.Money `json:"total_price"`
+	Currency       string      `json:"currency" db:"currency"`
+	QuotaRemaining int         `json:"quota_remaining" db:"quota_remaining"`
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	auditRepository "codebase-app/internal/module/audit/repository"
+	auditService "codebase-app/internal/module/audit/service"
+	"codebase-app/internal/module/campaign/entity"
+	"codebase-app/internal/module/campaign/ports"
+	"codebase-app/internal/module/campaign/repository"
+	"codebase-app/internal/module/campaign/service"
+	productRepository "codebase-app/internal/module/product/repository"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type campaignHandler struct {
+	service ports.CampaignService
+}
+
+func NewCampaignHandler() *campaignHandler {
+	var (
+		handler  = new(campaignHandler)
+		products = productRepository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		repo     = repository.NewCampaignRepository(adapter.Adapters.ShopeefunPostgres, products)
+		audit    = auditService.NewAuditService(auditRepository.NewAuditRepository(adapter.Adapters.ShopeefunPostgres))
+		service  = service.NewCampaignService(repo, audit)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *campaignHandler) Register(router fiber.Router) {
+	admin := []fiber.Handler{middleware.AuthBearer, middleware.AuthRole([]string{"admin"})}
+
+	router.Post("/campaigns", append(admin, h.CreateCampaign)...)
+	router.Get("/campaigns", h.GetCampaigns)
+	router.Get("/campaigns/:id", h.GetCampaign)
+	router.Delete("/campaigns/:id", append(admin, h.DeleteCampaign)...)
+	router.Get("/campaigns/:id/products", h.GetCampaignProducts)
+	router.Post("/campaigns/:id/products", middleware.UserIdHeader, h.EnrollProduct)
+	router.Delete("/campaigns/:id/products/:product_id", middleware.UserIdHeader, h.UnenrollProduct)
+	router.Post("/campaigns/:id/products/:product_id/purchase", middleware.UserIdHeader, h.Purchase)
+}
+
+func (h *campaignHandler) CreateCampaign(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateCampaignRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateCampaign - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateCampaign - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateCampaign(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, "Kampanye berhasil dibuat"))
+}
+
+func (h *campaignHandler) GetCampaigns(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetCampaignsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetCampaigns - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCampaigns - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCampaigns(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *campaignHandler) GetCampaign(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetCampaignRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCampaign - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCampaign(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *campaignHandler) DeleteCampaign(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteCampaignRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteCampaign - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.DeleteCampaign(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Kampanye berhasil dihapus"))
+}
+
+func (h *campaignHandler) GetCampaignProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetCampaignProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetCampaignProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.CampaignId = c.Params("id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCampaignProducts - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCampaignProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *campaignHandler) EnrollProduct(c *fiber.Ctx) error {
+	var (
+		req = new(entity.EnrollProductRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::EnrollProduct - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.CampaignId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::EnrollProduct - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.EnrollProduct(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Produk berhasil didaftarkan ke kampanye"))
+}
+
+func (h *campaignHandler) UnenrollProduct(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UnenrollProductRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.CampaignId = c.Params("id")
+	req.ProductId = c.Params("product_id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UnenrollProduct - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.UnenrollProduct(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Produk berhasil dikeluarkan dari kampanye"))
+}
+
+func (h *campaignHandler) Purchase(c *fiber.Ctx) error {
+	var (
+		req = new(entity.PurchaseRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::Purchase - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.CampaignId = c.Params("id")
+	req.ProductId = c.Params("product_id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::Purchase - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.Purchase(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Pembelian flash sale berhasil"))
+}
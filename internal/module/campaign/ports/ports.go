@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"codebase-app/internal/module/campaign/entity"
+	"context"
+)
+
+type CampaignRepository interface {
+	CreateCampaign(ctx context.Context, req *entity.CreateCampaignRequest) (*entity.CreateCampaignResponse, error)
+	GetCampaign(ctx context.Context, req *entity.GetCampaignRequest) (*entity.CampaignItem, error)
+	GetCampaigns(ctx context.Context, req *entity.GetCampaignsRequest) (*entity.GetCampaignsResponse, error)
+	DeleteCampaign(ctx context.Context, req *entity.DeleteCampaignRequest) error
+	GetProductOwnership(ctx context.Context, productId string) (*entity.ProductOwnership, error)
+	EnrollProduct(ctx context.Context, req *entity.EnrollProductRequest) (*entity.EnrollProductResponse, error)
+	UnenrollProduct(ctx context.Context, req *entity.UnenrollProductRequest) error
+	GetCampaignProducts(ctx context.Context, req *entity.GetCampaignProductsRequest) (*entity.GetCampaignProductsResponse, error)
+	Purchase(ctx context.Context, req *entity.PurchaseRequest) (*entity.PurchaseResponse, error)
+}
+
+type CampaignService interface {
+	CreateCampaign(ctx context.Context, req *entity.CreateCampaignRequest) (*entity.CreateCampaignResponse, error)
+	GetCampaign(ctx context.Context, req *entity.GetCampaignRequest) (*entity.CampaignItem, error)
+	GetCampaigns(ctx context.Context, req *entity.GetCampaignsRequest) (*entity.GetCampaignsResponse, error)
+	DeleteCampaign(ctx context.Context, req *entity.DeleteCampaignRequest) error
+	EnrollProduct(ctx context.Context, req *entity.EnrollProductRequest) (*entity.EnrollProductResponse, error)
+	UnenrollProduct(ctx context.Context, req *entity.UnenrollProductRequest) error
+	GetCampaignProducts(ctx context.Context, req *entity.GetCampaignProductsRequest) (*entity.GetCampaignProductsResponse, error)
+	Purchase(ctx context.Context, req *entity.PurchaseRequest) (*entity.PurchaseResponse, error)
+}
@@ -0,0 +1,380 @@
+package repository
+
+import (
+	"codebase-app/internal/module/campaign/entity"
+	"codebase-app/internal/module/campaign/ports"
+	productPorts "codebase-app/internal/module/product/ports"
+	productRepository "codebase-app/internal/module/product/repository"
+	"codebase-app/pkg/types"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.CampaignRepository = &campaignRepository{}
+
+type campaignRepository struct {
+	db       *sqlx.DB
+	products productPorts.ProductRepository
+}
+
+func NewCampaignRepository(db *sqlx.DB, products productPorts.ProductRepository) *campaignRepository {
+	return &campaignRepository{
+		db:       db,
+		products: products,
+	}
+}
+
+func (r *campaignRepository) CreateCampaign(ctx context.Context, req *entity.CreateCampaignRequest) (*entity.CreateCampaignResponse, error) {
+	var resp = new(entity.CreateCampaignResponse)
+
+	query := `
+		INSERT INTO campaigns (name, description, starts_at, ends_at, created_by)
+		VALUES (?, NULLIF(?, ''), ?, ?, ?)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Name,
+		req.Description,
+		req.StartsAt,
+		req.EndsAt,
+		req.UserId).Scan(&resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateCampaign - Failed to create campaign")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *campaignRepository) GetCampaign(ctx context.Context, req *entity.GetCampaignRequest) (*entity.CampaignItem, error) {
+	var resp = new(entity.CampaignItem)
+
+	query := `
+		SELECT id, name, COALESCE(description, '') as description, starts_at, ends_at
+		FROM campaigns
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetCampaign - Failed to get campaign")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *campaignRepository) GetCampaigns(ctx context.Context, req *entity.GetCampaignsRequest) (*entity.GetCampaignsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.CampaignItem
+	}
+
+	var (
+		resp  = new(entity.GetCampaignsResponse)
+		data  = make([]dao, 0, req.Paginate)
+		query = `
+			SELECT
+				COUNT(id) OVER() as total_data,
+				id,
+				name,
+				COALESCE(description, '') as description,
+				starts_at,
+				ends_at
+			FROM campaigns
+			WHERE deleted_at IS NULL
+		`
+	)
+	resp.Items = make([]entity.CampaignItem, 0, req.Paginate)
+
+	switch req.State {
+	case "active":
+		query += " AND starts_at <= NOW() AND ends_at > NOW()"
+	case "upcoming":
+		query += " AND starts_at > NOW()"
+	case "ended":
+		query += " AND ends_at <= NOW()"
+	}
+
+	query += " ORDER BY starts_at DESC LIMIT ? OFFSET ?"
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), req.Paginate, (req.Page-1)*req.Paginate)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetCampaigns - Failed to get campaigns")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.CampaignItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *campaignRepository) DeleteCampaign(ctx context.Context, req *entity.DeleteCampaignRequest) error {
+	query := `UPDATE campaigns SET deleted_at = NOW(), updated_at = NOW() WHERE id = ? AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCampaign - Failed to delete campaign")
+		return err
+	}
+
+	return nil
+}
+
+func (r *campaignRepository) GetProductOwnership(ctx context.Context, productId string) (*entity.ProductOwnership, error) {
+	var resp = new(entity.ProductOwnership)
+
+	query := `
+		SELECT
+			p.id,
+			p.shop_id,
+			s.user_id,
+			p.price,
+			s.currency,
+			` + productRepository.AvailableStock + ` as stock
+		FROM product p
+		JOIN shops s ON s.id = p.shop_id
+		WHERE p.id = ? AND p.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), productId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::GetProductOwnership - Failed to get product ownership")
+		return nil, err
+	}
+
+	if resp.Price, err = resp.Price.WithCurrency(resp.Currency); err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::GetProductOwnership - Failed to set currency")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// EnrollProduct enrolls a product or changes its campaign price and quota.
+// The quota can never drop below what has already been sold.
+func (r *campaignRepository) EnrollProduct(ctx context.Context, req *entity.EnrollProductRequest) (*entity.EnrollProductResponse, error) {
+	var resp = new(entity.EnrollProductResponse)
+
+	query := `
+		INSERT INTO campaign_products (campaign_id, product_id, shop_id, campaign_price, quota)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (campaign_id, product_id) DO UPDATE
+		SET
+			campaign_price = EXCLUDED.campaign_price,
+			quota = EXCLUDED.quota,
+			updated_at = NOW()
+		WHERE campaign_products.sold <= EXCLUDED.quota
+		RETURNING campaign_id, product_id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.CampaignId,
+		req.ProductId,
+		req.ShopId,
+		req.CampaignPrice,
+		req.Quota).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::EnrollProduct - Failed to enroll product")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *campaignRepository) UnenrollProduct(ctx context.Context, req *entity.UnenrollProductRequest) error {
+	query := `
+		DELETE FROM campaign_products cp
+		USING campaigns c, shops s
+		WHERE
+			c.id = cp.campaign_id
+			AND s.id = cp.shop_id
+			AND cp.campaign_id = ?
+			AND cp.product_id = ?
+			AND s.user_id = ?
+			AND c.starts_at > NOW()
+	`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.CampaignId, req.ProductId, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UnenrollProduct - Failed to unenroll product")
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *campaignRepository) GetCampaignProducts(ctx context.Context, req *entity.GetCampaignProductsRequest) (*entity.GetCampaignProductsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.CampaignProductItem
+	}
+
+	var (
+		resp = new(entity.GetCampaignProductsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.CampaignProductItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(cp.product_id) OVER() as total_data,
+			cp.product_id,
+			p.name,
+			p.shop_id,
+			COALESCE(p.image_url, '') as image_url,
+			p.price,
+			cp.campaign_price,
+			s.currency,
+			cp.quota,
+			cp.quota - cp.sold as quota_remaining
+		FROM campaign_products cp
+		JOIN product p ON p.id = cp.product_id
+		JOIN shops s ON s.id = p.shop_id
+		WHERE
+			cp.campaign_id = ?
+			AND p.deleted_at IS NULL
+			AND p.status = 'active'
+		ORDER BY (cp.quota - cp.sold) = 0, cp.campaign_price, cp.product_id
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), req.CampaignId, req.Paginate, (req.Page-1)*req.Paginate)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetCampaignProducts - Failed to get campaign products")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		item := d.CampaignProductItem
+		for _, amount := range []*types.Money{&item.Price, &item.CampaignPrice} {
+			if *amount, err = amount.WithCurrency(item.Currency); err != nil {
+				log.Error().Err(err).Any("payload", req).Msg("repository::GetCampaignProducts - Failed to set currency")
+				return nil, err
+			}
+		}
+		resp.Items = append(resp.Items, item)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// Purchase takes quantity out of the campaign quota and the product stock in
+// one transaction, see ProductRepository.TakeStock. Each UPDATE checks its own
+// limit, so concurrent purchases serialize on the row lock and can never
+// oversell.
+func (r *campaignRepository) Purchase(ctx context.Context, req *entity.PurchaseRequest) (*entity.PurchaseResponse, error) {
+	var resp = &entity.PurchaseResponse{
+		CampaignId: req.CampaignId,
+		ProductId:  req.ProductId,
+		Quantity:   req.Quantity,
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::Purchase - Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::Purchase - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	// the row lock keeps the product from being deactivated or deleted until
+	// the purchase commits. It is FOR UPDATE rather than FOR SHARE because
+	// TakeStock locks the same row again to take its stock, and two
+	// purchases upgrading a shared lock would deadlock.
+	var available bool
+	err = tx.QueryRowxContext(ctx, tx.Rebind(`
+		SELECT status = 'active'
+		FROM product
+		WHERE id = ? AND deleted_at IS NULL
+		FOR UPDATE
+	`), req.ProductId).Scan(&available)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !available) {
+		err = entity.ErrProductUnavailable
+		return nil, err
+	}
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::Purchase - Failed to lock product")
+		return nil, err
+	}
+
+	query := `
+		UPDATE campaign_products cp
+		SET sold = cp.sold + ?, updated_at = NOW()
+		FROM campaigns c
+		WHERE
+			c.id = cp.campaign_id
+			AND cp.campaign_id = ?
+			AND cp.product_id = ?
+			AND c.deleted_at IS NULL
+			AND c.starts_at <= NOW()
+			AND c.ends_at > NOW()
+			AND cp.sold + ? <= cp.quota
+		RETURNING
+			cp.campaign_price,
+			(SELECT s.currency FROM shops s WHERE s.id = cp.shop_id) as currency,
+			cp.quota - cp.sold as quota_remaining
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		req.Quantity,
+		req.CampaignId,
+		req.ProductId,
+		req.Quantity).StructScan(resp)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = entity.ErrQuotaExhausted
+		return nil, err
+	}
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::Purchase - Failed to take campaign quota")
+		return nil, err
+	}
+
+	if err = r.products.TakeStock(ctx, tx, req.ProductId, req.Quantity); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::Purchase - Failed to commit transaction")
+		return nil, err
+	}
+
+	// the price was scanned without a currency, the purchase is already
+	// committed so a relabel error is only logged
+	if unitPrice, errCurrency := resp.UnitPrice.WithCurrency(resp.Currency); errCurrency != nil {
+		log.Error().Err(errCurrency).Any("payload", req).Msg("repository::Purchase - Failed to set currency")
+	} else {
+		resp.UnitPrice = unitPrice
+	}
+	resp.TotalPrice = resp.UnitPrice.Mul(int64(req.Quantity))
+
+	return resp, nil
+}
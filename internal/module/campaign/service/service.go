@@ -0,0 +1,161 @@
+package service
+
+import (
+	auditEntity "codebase-app/internal/module/audit/entity"
+	auditPorts "codebase-app/internal/module/audit/ports"
+	"codebase-app/internal/module/campaign/entity"
+	"codebase-app/internal/module/campaign/ports"
+	productEntity "codebase-app/internal/module/product/entity"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var _ ports.CampaignService = &campaignService{}
+
+type campaignService struct {
+	repo  ports.CampaignRepository
+	audit auditPorts.AuditService
+}
+
+func NewCampaignService(repo ports.CampaignRepository, audit auditPorts.AuditService) *campaignService {
+	return &campaignService{
+		repo:  repo,
+		audit: audit,
+	}
+}
+
+func (s *campaignService) CreateCampaign(ctx context.Context, req *entity.CreateCampaignRequest) (*entity.CreateCampaignResponse, error) {
+	resp, err := s.repo.CreateCampaign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityCampaign,
+		EntityId: resp.Id,
+		Action:   auditEntity.ActionCreate,
+		After:    req,
+	})
+
+	return resp, nil
+}
+
+func (s *campaignService) GetCampaign(ctx context.Context, req *entity.GetCampaignRequest) (*entity.CampaignItem, error) {
+	resp, err := s.repo.GetCampaign(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Kampanye tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *campaignService) GetCampaigns(ctx context.Context, req *entity.GetCampaignsRequest) (*entity.GetCampaignsResponse, error) {
+	return s.repo.GetCampaigns(ctx, req)
+}
+
+func (s *campaignService) DeleteCampaign(ctx context.Context, req *entity.DeleteCampaignRequest) error {
+	before, err := s.GetCampaign(ctx, &entity.GetCampaignRequest{Id: req.Id})
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteCampaign(ctx, req); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityCampaign,
+		EntityId: req.Id,
+		Action:   auditEntity.ActionDelete,
+		Before:   before,
+	})
+
+	return nil
+}
+
+func (s *campaignService) EnrollProduct(ctx context.Context, req *entity.EnrollProductRequest) (*entity.EnrollProductResponse, error) {
+	campaign, err := s.GetCampaign(ctx, &entity.GetCampaignRequest{Id: req.CampaignId})
+	if err != nil {
+		return nil, err
+	}
+
+	if !campaign.EndsAt.After(time.Now()) {
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Kampanye sudah berakhir"))
+	}
+
+	product, err := s.repo.GetProductOwnership(ctx, req.ProductId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if product.UserId != req.UserId {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke produk ini"))
+	}
+
+	if req.CampaignPrice.Currency != product.Currency {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("campaign_price", productEntity.CurrencyMessage(product.Currency)))
+	}
+	if cmp, err := req.CampaignPrice.Cmp(product.Price); err != nil || cmp >= 0 {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("campaign_price", "campaign price harus kurang dari harga produk."))
+	}
+
+	if req.Quota > product.Stock {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("quota", "quota tidak boleh melebihi stok produk."))
+	}
+
+	req.ShopId = product.ShopId
+
+	resp, err := s.repo.EnrollProduct(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("quota", "quota tidak boleh kurang dari jumlah yang sudah terjual."))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *campaignService) UnenrollProduct(ctx context.Context, req *entity.UnenrollProductRequest) error {
+	err := s.repo.UnenrollProduct(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak terdaftar di kampanye yang belum dimulai"))
+	}
+
+	return err
+}
+
+func (s *campaignService) GetCampaignProducts(ctx context.Context, req *entity.GetCampaignProductsRequest) (*entity.GetCampaignProductsResponse, error) {
+	if _, err := s.GetCampaign(ctx, &entity.GetCampaignRequest{Id: req.CampaignId}); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCampaignProducts(ctx, req)
+}
+
+func (s *campaignService) Purchase(ctx context.Context, req *entity.PurchaseRequest) (*entity.PurchaseResponse, error) {
+	resp, err := s.repo.Purchase(ctx, req)
+	switch {
+	case errors.Is(err, entity.ErrQuotaExhausted):
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Kuota flash sale habis atau kampanye tidak aktif"))
+	case errors.Is(err, productEntity.ErrOutOfStock):
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Stok produk tidak mencukupi"))
+	case errors.Is(err, entity.ErrProductUnavailable):
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Produk tidak tersedia"))
+	case err != nil:
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,156 @@
+package entity
+
+import (
+	productEntity "codebase-app/internal/module/product/entity"
+	"codebase-app/pkg/types"
+	"time"
+)
+
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+
+	// MaxProducts is how many products a collection can hold.
+	MaxProducts = 200
+)
+
+type CollectionItem struct {
+	Id           string     `json:"id" db:"id"`
+	ShopId       string     `json:"shop_id" db:"shop_id"`
+	Name         string     `json:"name" db:"name"`
+	Description  string     `json:"description" db:"description"`
+	Status       string     `json:"status" db:"status"`
+	ProductCount int        `json:"product_count" db:"product_count"`
+	PublishedAt  *time.Time `json:"published_at" db:"published_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type CreateCollectionRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ShopId      string `json:"shop_id" validate:"required,uuid" db:"shop_id"`
+	Name        string `json:"name" validate:"required,min=3,max=100" db:"name"`
+	Description string `json:"description" validate:"max=255" db:"description"`
+}
+
+type UpdateCollectionRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id          string `params:"id" validate:"uuid" db:"id"`
+	Name        string `json:"name" validate:"required,min=3,max=100" db:"name"`
+	Description string `json:"description" validate:"max=255" db:"description"`
+}
+
+// CollectionRequest identifies a collection the user wants to change.
+type CollectionRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+}
+
+type GetCollectionRequest struct {
+	Id string `params:"id" validate:"uuid"`
+}
+
+// GetCollectionsRequest lists the collections of the user's shop, drafts
+// included.
+type GetCollectionsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ShopId   string `query:"shop_id" validate:"required,uuid"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *GetCollectionsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+// GetShopCollectionsRequest lists the published collections of a shop.
+type GetShopCollectionsRequest struct {
+	ShopId   string `params:"id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *GetShopCollectionsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type GetCollectionsResponse struct {
+	Items []CollectionItem `json:"items"`
+	Meta  types.Meta       `json:"meta"`
+}
+
+type AddCollectionProductsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id         string   `params:"id" validate:"uuid"`
+	ProductIds []string `json:"product_ids" validate:"required,min=1,max=100,dive,uuid"`
+}
+
+// ReorderCollectionProductsRequest lists every product of the collection in
+// the new order.
+type ReorderCollectionProductsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id         string   `params:"id" validate:"uuid"`
+	ProductIds []string `json:"product_ids" validate:"required,max=200,dive,uuid"`
+}
+
+type RemoveCollectionProductRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id        string `params:"id" validate:"uuid"`
+	ProductId string `params:"product_id" validate:"uuid"`
+}
+
+// GetCollectionProductsRequest pages through the products of a published
+// collection in the order the seller set.
+type GetCollectionProductsRequest struct {
+	Id       string `params:"id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *GetCollectionProductsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type GetCollectionProductsResponse struct {
+	Items []productEntity.ProductItem `json:"items"`
+	Meta  types.Meta                  `json:"meta"`
+}
+
+// CollectionOwnership is used to authorize changes to a collection.
+type CollectionOwnership struct {
+	Id     string `db:"id"`
+	ShopId string `db:"shop_id"`
+	UserId string `db:"user_id"`
+	Status string `db:"status"`
+}
+
+// ShopOwnership is used to authorize creating collections in a shop.
+type ShopOwnership struct {
+	Id     string `db:"id"`
+	UserId string `db:"user_id"`
+}
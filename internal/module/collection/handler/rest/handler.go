@@ -0,0 +1,389 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/collection/entity"
+	"codebase-app/internal/module/collection/ports"
+	"codebase-app/internal/module/collection/repository"
+	"codebase-app/internal/module/collection/service"
+	productRepository "codebase-app/internal/module/product/repository"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type collectionHandler struct {
+	service ports.CollectionService
+}
+
+func NewCollectionHandler() *collectionHandler {
+	var (
+		handler  = new(collectionHandler)
+		repo     = repository.NewCollectionRepository(adapter.Adapters.ShopeefunPostgres)
+		products = productRepository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		service  = service.NewCollectionService(repo, products)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *collectionHandler) Register(router fiber.Router) {
+	router.Post("/collections", middleware.UserIdHeader, h.CreateCollection)
+	router.Get("/collections", middleware.UserIdHeader, h.GetCollections)
+	router.Get("/collections/:id", h.GetCollection)
+	router.Patch("/collections/:id", middleware.UserIdHeader, h.UpdateCollection)
+	router.Delete("/collections/:id", middleware.UserIdHeader, h.DeleteCollection)
+	router.Post("/collections/:id/publish", middleware.UserIdHeader, h.PublishCollection)
+	router.Delete("/collections/:id/publish", middleware.UserIdHeader, h.UnpublishCollection)
+	router.Get("/collections/:id/products", h.GetCollectionProducts)
+	router.Post("/collections/:id/products", middleware.UserIdHeader, h.AddProducts)
+	router.Put("/collections/:id/products", middleware.UserIdHeader, h.ReorderProducts)
+	router.Delete("/collections/:id/products/:product_id", middleware.UserIdHeader, h.RemoveProduct)
+	router.Get("/shops/:id/collections", h.GetShopCollections)
+}
+
+func (h *collectionHandler) CreateCollection(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateCollectionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateCollection - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateCollection - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateCollection(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, "Koleksi berhasil dibuat"))
+}
+
+func (h *collectionHandler) GetCollections(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetCollectionsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetCollections - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCollections - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCollections(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *collectionHandler) GetCollection(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetCollectionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCollection - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCollection(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *collectionHandler) UpdateCollection(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpdateCollectionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateCollection - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateCollection - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateCollection(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Koleksi berhasil diupdate"))
+}
+
+func (h *collectionHandler) DeleteCollection(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CollectionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteCollection - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.DeleteCollection(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Koleksi berhasil dihapus"))
+}
+
+func (h *collectionHandler) PublishCollection(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CollectionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::PublishCollection - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.PublishCollection(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Koleksi berhasil dipublikasikan"))
+}
+
+func (h *collectionHandler) UnpublishCollection(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CollectionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UnpublishCollection - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UnpublishCollection(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Koleksi berhasil disembunyikan"))
+}
+
+func (h *collectionHandler) GetCollectionProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetCollectionProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetCollectionProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCollectionProducts - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCollectionProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *collectionHandler) AddProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.AddCollectionProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::AddProducts - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::AddProducts - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.AddProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Produk berhasil ditambahkan ke koleksi"))
+}
+
+func (h *collectionHandler) ReorderProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.ReorderCollectionProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::ReorderProducts - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ReorderProducts - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.ReorderProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Urutan produk koleksi berhasil disimpan"))
+}
+
+func (h *collectionHandler) RemoveProduct(c *fiber.Ctx) error {
+	var (
+		req = new(entity.RemoveCollectionProductRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+	req.ProductId = c.Params("product_id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::RemoveProduct - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.RemoveProduct(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Produk berhasil dihapus dari koleksi"))
+}
+
+func (h *collectionHandler) GetShopCollections(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetShopCollectionsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetShopCollections - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetShopCollections - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetShopCollections(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"codebase-app/internal/module/collection/entity"
+	"context"
+)
+
+type CollectionRepository interface {
+	GetShopOwnership(ctx context.Context, shopId string) (*entity.ShopOwnership, error)
+	GetCollectionOwnership(ctx context.Context, id string) (*entity.CollectionOwnership, error)
+	CountShopProducts(ctx context.Context, shopId string, productIds []string) (int, error)
+	CreateCollection(ctx context.Context, req *entity.CreateCollectionRequest) (*entity.CollectionItem, error)
+	UpdateCollection(ctx context.Context, req *entity.UpdateCollectionRequest) error
+	DeleteCollection(ctx context.Context, id string) error
+	SetStatus(ctx context.Context, id, status string) error
+	GetCollection(ctx context.Context, id string) (*entity.CollectionItem, error)
+	GetCollections(ctx context.Context, shopId, status string, page, paginate int) (*entity.GetCollectionsResponse, error)
+	GetProductIds(ctx context.Context, id string) ([]string, error)
+	AddProducts(ctx context.Context, id string, productIds []string) error
+	ReorderProducts(ctx context.Context, id string, productIds []string) error
+	RemoveProduct(ctx context.Context, id, productId string) error
+	// GetActiveProductIds pages through the ids of the active products of the
+	// collection in collection order.
+	GetActiveProductIds(ctx context.Context, id string, page, paginate int) ([]string, int, error)
+}
+
+type CollectionService interface {
+	CreateCollection(ctx context.Context, req *entity.CreateCollectionRequest) (*entity.CollectionItem, error)
+	UpdateCollection(ctx context.Context, req *entity.UpdateCollectionRequest) (*entity.CollectionItem, error)
+	DeleteCollection(ctx context.Context, req *entity.CollectionRequest) error
+	PublishCollection(ctx context.Context, req *entity.CollectionRequest) (*entity.CollectionItem, error)
+	UnpublishCollection(ctx context.Context, req *entity.CollectionRequest) (*entity.CollectionItem, error)
+	GetCollections(ctx context.Context, req *entity.GetCollectionsRequest) (*entity.GetCollectionsResponse, error)
+	GetShopCollections(ctx context.Context, req *entity.GetShopCollectionsRequest) (*entity.GetCollectionsResponse, error)
+	GetCollection(ctx context.Context, req *entity.GetCollectionRequest) (*entity.CollectionItem, error)
+	AddProducts(ctx context.Context, req *entity.AddCollectionProductsRequest) (*entity.CollectionItem, error)
+	ReorderProducts(ctx context.Context, req *entity.ReorderCollectionProductsRequest) (*entity.CollectionItem, error)
+	RemoveProduct(ctx context.Context, req *entity.RemoveCollectionProductRequest) (*entity.CollectionItem, error)
+	GetCollectionProducts(ctx context.Context, req *entity.GetCollectionProductsRequest) (*entity.GetCollectionProductsResponse, error)
+}
@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"codebase-app/internal/module/collection/entity"
+	"codebase-app/internal/module/collection/ports"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.CollectionRepository = &collectionRepository{}
+
+// collectionColumns selects a collection aliased as c. product_count counts
+// every product the seller added, including inactive ones.
+const collectionColumns = `
+	c.id,
+	c.shop_id,
+	c.name,
+	c.description,
+	c.status,
+	(SELECT COUNT(*) FROM collection_products cp WHERE cp.collection_id = c.id) as product_count,
+	c.published_at,
+	c.created_at,
+	c.updated_at
+`
+
+type collectionRepository struct {
+	db *sqlx.DB
+}
+
+func NewCollectionRepository(db *sqlx.DB) *collectionRepository {
+	return &collectionRepository{
+		db: db,
+	}
+}
+
+func (r *collectionRepository) GetShopOwnership(ctx context.Context, shopId string) (*entity.ShopOwnership, error) {
+	var resp = new(entity.ShopOwnership)
+
+	query := `SELECT id, user_id FROM shops WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), shopId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetShopOwnership - Failed to get shop ownership")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *collectionRepository) GetCollectionOwnership(ctx context.Context, id string) (*entity.CollectionOwnership, error) {
+	var resp = new(entity.CollectionOwnership)
+
+	query := `
+		SELECT c.id, c.shop_id, s.user_id, c.status
+		FROM collections c
+		JOIN shops s ON s.id = c.shop_id
+		WHERE c.id = ? AND c.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetCollectionOwnership - Failed to get collection ownership")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CountShopProducts returns how many of productIds belong to the shop.
+func (r *collectionRepository) CountShopProducts(ctx context.Context, shopId string, productIds []string) (int, error) {
+	var count int
+
+	query := `SELECT COUNT(id) FROM product WHERE shop_id = ? AND id = ANY(?::uuid[]) AND deleted_at IS NULL`
+
+	err := r.db.GetContext(ctx, &count, r.db.Rebind(query), shopId, pq.Array(productIds))
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::CountShopProducts - Failed to count shop products")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *collectionRepository) CreateCollection(ctx context.Context, req *entity.CreateCollectionRequest) (*entity.CollectionItem, error) {
+	var id string
+
+	query := `INSERT INTO collections (shop_id, name, description) VALUES (?, ?, ?) RETURNING id`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.ShopId, req.Name, req.Description).Scan(&id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateCollection - Failed to create collection")
+		return nil, err
+	}
+
+	return r.GetCollection(ctx, id)
+}
+
+func (r *collectionRepository) UpdateCollection(ctx context.Context, req *entity.UpdateCollectionRequest) error {
+	query := `
+		UPDATE collections
+		SET name = ?, description = ?, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Name, req.Description, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCollection - Failed to update collection")
+		return err
+	}
+
+	return nil
+}
+
+func (r *collectionRepository) DeleteCollection(ctx context.Context, id string) error {
+	query := `UPDATE collections SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::DeleteCollection - Failed to delete collection")
+		return err
+	}
+
+	return nil
+}
+
+// SetStatus keeps published_at at the first time the collection was
+// published.
+func (r *collectionRepository) SetStatus(ctx context.Context, id, status string) error {
+	query := `
+		UPDATE collections
+		SET
+			status = ?,
+			published_at = CASE WHEN ? = 'published' THEN COALESCE(published_at, NOW()) ELSE published_at END,
+			updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), status, status, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Str("status", status).Msg("repository::SetStatus - Failed to set collection status")
+		return err
+	}
+
+	return nil
+}
+
+func (r *collectionRepository) GetCollection(ctx context.Context, id string) (*entity.CollectionItem, error) {
+	var resp = new(entity.CollectionItem)
+
+	query := `SELECT ` + collectionColumns + ` FROM collections c WHERE c.id = ? AND c.deleted_at IS NULL`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("repository::GetCollection - Failed to get collection")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetCollections lists the collections of a shop, only those with status
+// unless status is empty.
+func (r *collectionRepository) GetCollections(ctx context.Context, shopId, status string, page, paginate int) (*entity.GetCollectionsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.CollectionItem
+	}
+
+	var (
+		resp = new(entity.GetCollectionsResponse)
+		data = make([]dao, 0, paginate)
+	)
+	resp.Items = make([]entity.CollectionItem, 0, paginate)
+
+	query := `
+		SELECT
+			COUNT(c.id) OVER() as total_data,
+			` + collectionColumns + `
+		FROM collections c
+		WHERE c.shop_id = ? AND c.deleted_at IS NULL AND (? = '' OR c.status = ?)
+		ORDER BY c.created_at DESC, c.id
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), shopId, status, status, paginate, (page-1)*paginate)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Str("status", status).Msg("repository::GetCollections - Failed to get collections")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.CollectionItem)
+	}
+
+	resp.Meta.CountTotalPage(page, paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *collectionRepository) GetProductIds(ctx context.Context, id string) ([]string, error) {
+	var ids = make([]string, 0)
+
+	query := `SELECT product_id FROM collection_products WHERE collection_id = ? ORDER BY position, created_at`
+
+	err := r.db.SelectContext(ctx, &ids, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetProductIds - Failed to get collection products")
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// AddProducts appends the products after the last one, in the given order.
+// Products already in the collection keep their position.
+func (r *collectionRepository) AddProducts(ctx context.Context, id string, productIds []string) error {
+	query := `
+		INSERT INTO collection_products (collection_id, product_id, position)
+		SELECT
+			?,
+			x.product_id,
+			(SELECT COALESCE(MAX(position), 0) FROM collection_products WHERE collection_id = ?) + x.ord
+		FROM UNNEST(?::uuid[]) WITH ORDINALITY AS x(product_id, ord)
+		ON CONFLICT (collection_id, product_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), id, id, pq.Array(productIds))
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Strs("product_ids", productIds).Msg("repository::AddProducts - Failed to add collection products")
+		return err
+	}
+
+	return nil
+}
+
+// ReorderProducts sets the position of each product to its index in
+// productIds.
+func (r *collectionRepository) ReorderProducts(ctx context.Context, id string, productIds []string) error {
+	query := `
+		UPDATE collection_products cp
+		SET position = x.ord
+		FROM UNNEST(?::uuid[]) WITH ORDINALITY AS x(product_id, ord)
+		WHERE cp.collection_id = ? AND cp.product_id = x.product_id
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), pq.Array(productIds), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Strs("product_ids", productIds).Msg("repository::ReorderProducts - Failed to reorder collection products")
+		return err
+	}
+
+	return nil
+}
+
+func (r *collectionRepository) RemoveProduct(ctx context.Context, id, productId string) error {
+	query := `DELETE FROM collection_products WHERE collection_id = ? AND product_id = ?`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), id, productId)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Str("product_id", productId).Msg("repository::RemoveProduct - Failed to remove collection product")
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *collectionRepository) GetActiveProductIds(ctx context.Context, id string, page, paginate int) ([]string, int, error) {
+	type dao struct {
+		TotalData int    `db:"total_data"`
+		ProductId string `db:"product_id"`
+	}
+
+	var data = make([]dao, 0, paginate)
+
+	query := `
+		SELECT COUNT(cp.product_id) OVER() as total_data, cp.product_id
+		FROM collection_products cp
+		JOIN product p ON p.id = cp.product_id
+		WHERE cp.collection_id = ? AND p.deleted_at IS NULL AND p.status = 'active'
+		ORDER BY cp.position, cp.created_at
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), id, paginate, (page-1)*paginate)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetActiveProductIds - Failed to get collection products")
+		return nil, 0, err
+	}
+
+	var (
+		ids   = make([]string, 0, len(data))
+		total int
+	)
+	for _, d := range data {
+		ids = append(ids, d.ProductId)
+		total = d.TotalData
+	}
+
+	return ids, total, nil
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"codebase-app/internal/module/collection/entity"
+	"codebase-app/internal/module/collection/ports"
+	productPorts "codebase-app/internal/module/product/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var _ ports.CollectionService = &collectionService{}
+
+type collectionService struct {
+	repo     ports.CollectionRepository
+	products productPorts.ProductRepository
+}
+
+// NewCollectionService takes the product repository to show collection
+// products with the same prices as the product listing.
+func NewCollectionService(repo ports.CollectionRepository, products productPorts.ProductRepository) *collectionService {
+	return &collectionService{
+		repo:     repo,
+		products: products,
+	}
+}
+
+func (s *collectionService) CreateCollection(ctx context.Context, req *entity.CreateCollectionRequest) (*entity.CollectionItem, error) {
+	shop, err := s.repo.GetShopOwnership(ctx, req.ShopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if shop.UserId != req.UserId {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke toko ini"))
+	}
+
+	return s.repo.CreateCollection(ctx, req)
+}
+
+func (s *collectionService) UpdateCollection(ctx context.Context, req *entity.UpdateCollectionRequest) (*entity.CollectionItem, error) {
+	if _, err := s.authorize(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateCollection(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCollection(ctx, req.Id)
+}
+
+func (s *collectionService) DeleteCollection(ctx context.Context, req *entity.CollectionRequest) error {
+	if _, err := s.authorize(ctx, req.Id, req.UserId); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteCollection(ctx, req.Id)
+}
+
+// PublishCollection makes the collection visible on the storefront. An empty
+// collection cannot be published.
+func (s *collectionService) PublishCollection(ctx context.Context, req *entity.CollectionRequest) (*entity.CollectionItem, error) {
+	if _, err := s.authorize(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	ids, err := s.repo.GetProductIds(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, errmsg.NewCustomErrors(422, errmsg.WithMessage("Koleksi kosong tidak dapat dipublikasikan"))
+	}
+
+	if err := s.repo.SetStatus(ctx, req.Id, entity.StatusPublished); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCollection(ctx, req.Id)
+}
+
+func (s *collectionService) UnpublishCollection(ctx context.Context, req *entity.CollectionRequest) (*entity.CollectionItem, error) {
+	if _, err := s.authorize(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetStatus(ctx, req.Id, entity.StatusDraft); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCollection(ctx, req.Id)
+}
+
+func (s *collectionService) GetCollections(ctx context.Context, req *entity.GetCollectionsRequest) (*entity.GetCollectionsResponse, error) {
+	shop, err := s.repo.GetShopOwnership(ctx, req.ShopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if shop.UserId != req.UserId {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke toko ini"))
+	}
+
+	return s.repo.GetCollections(ctx, req.ShopId, "", req.Page, req.Paginate)
+}
+
+func (s *collectionService) GetShopCollections(ctx context.Context, req *entity.GetShopCollectionsRequest) (*entity.GetCollectionsResponse, error) {
+	return s.repo.GetCollections(ctx, req.ShopId, entity.StatusPublished, req.Page, req.Paginate)
+}
+
+func (s *collectionService) GetCollection(ctx context.Context, req *entity.GetCollectionRequest) (*entity.CollectionItem, error) {
+	return s.getPublished(ctx, req.Id)
+}
+
+// AddProducts appends products of the collection's shop. Products already in
+// the collection are ignored.
+func (s *collectionService) AddProducts(ctx context.Context, req *entity.AddCollectionProductsRequest) (*entity.CollectionItem, error) {
+	collection, err := s.authorize(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ProductIds = unique(req.ProductIds)
+
+	count, err := s.repo.CountShopProducts(ctx, collection.ShopId, req.ProductIds)
+	if err != nil {
+		return nil, err
+	}
+	if count != len(req.ProductIds) {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("product_ids", "semua produk harus milik toko ini."))
+	}
+
+	current, err := s.repo.GetProductIds(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	added := 0
+	for _, id := range req.ProductIds {
+		if !slices.Contains(current, id) {
+			added++
+		}
+	}
+	if len(current)+added > entity.MaxProducts {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("product_ids", fmt.Sprintf("koleksi maksimal berisi %d produk.", entity.MaxProducts)))
+	}
+
+	if err := s.repo.AddProducts(ctx, req.Id, req.ProductIds); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCollection(ctx, req.Id)
+}
+
+// ReorderProducts expects exactly the products already in the collection.
+func (s *collectionService) ReorderProducts(ctx context.Context, req *entity.ReorderCollectionProductsRequest) (*entity.CollectionItem, error) {
+	if _, err := s.authorize(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.GetProductIds(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		want = slices.Clone(current)
+		got  = unique(req.ProductIds)
+	)
+	slices.Sort(want)
+	slices.Sort(got)
+	if len(got) != len(req.ProductIds) || !slices.Equal(want, got) {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("product_ids", "product_ids harus berisi semua produk koleksi tepat satu kali."))
+	}
+
+	if err := s.repo.ReorderProducts(ctx, req.Id, req.ProductIds); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCollection(ctx, req.Id)
+}
+
+func (s *collectionService) RemoveProduct(ctx context.Context, req *entity.RemoveCollectionProductRequest) (*entity.CollectionItem, error) {
+	if _, err := s.authorize(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	err := s.repo.RemoveProduct(ctx, req.Id, req.ProductId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ada di koleksi ini"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCollection(ctx, req.Id)
+}
+
+// GetCollectionProducts lists the active products of a published collection
+// in the order the seller set.
+func (s *collectionService) GetCollectionProducts(ctx context.Context, req *entity.GetCollectionProductsRequest) (*entity.GetCollectionProductsResponse, error) {
+	if _, err := s.getPublished(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	ids, total, err := s.repo.GetActiveProductIds(ctx, req.Id, req.Page, req.Paginate)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.products.GetProductsByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &entity.GetCollectionProductsResponse{Items: items}
+	resp.Meta.TotalData = total
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, total)
+
+	return resp, nil
+}
+
+func (s *collectionService) getPublished(ctx context.Context, id string) (*entity.CollectionItem, error) {
+	collection, err := s.repo.GetCollection(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && collection.Status != entity.StatusPublished) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Koleksi tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (s *collectionService) authorize(ctx context.Context, id, userId string) (*entity.CollectionOwnership, error) {
+	collection, err := s.repo.GetCollectionOwnership(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Koleksi tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if collection.UserId != userId {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke koleksi ini"))
+	}
+
+	return collection, nil
+}
+
+// unique removes repeated ids, keeping the first occurrence.
+func unique(ids []string) []string {
+	var (
+		seen = make(map[string]bool, len(ids))
+		resp = make([]string, 0, len(ids))
+	)
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			resp = append(resp, id)
+		}
+	}
+
+	return resp
+}
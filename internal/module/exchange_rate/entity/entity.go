@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrRateNotFound is returned when no rate is known for a currency pair in
+// either direction.
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// ExchangeRate is how many units of QuoteCurrency one unit of BaseCurrency
+// buys. Rate is a decimal string so no precision is lost on the way in.
+type ExchangeRate struct {
+	BaseCurrency  string    `json:"base_currency" validate:"required,currency" db:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency" validate:"required,currency" db:"quote_currency"`
+	Rate          string    `json:"rate" validate:"required,numeric" db:"rate"`
+	UpdatedBy     string    `json:"updated_by" db:"updated_by"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type SetExchangeRatesRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Rates []ExchangeRate `json:"rates" validate:"required,min=1,max=500,dive"`
+}
+
+type GetExchangeRatesResponse struct {
+	Items []ExchangeRate `json:"items"`
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/exchange_rate/entity"
+	"codebase-app/internal/module/exchange_rate/ports"
+	"codebase-app/internal/module/exchange_rate/repository"
+	"codebase-app/internal/module/exchange_rate/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type exchangeRateHandler struct {
+	service ports.ExchangeRateService
+}
+
+func NewExchangeRateHandler() *exchangeRateHandler {
+	var (
+		handler = new(exchangeRateHandler)
+		repo    = repository.NewExchangeRateRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewExchangeRateService(repo)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *exchangeRateHandler) Register(router fiber.Router) {
+	router.Get("/exchange-rates", h.GetRates)
+	router.Put("/exchange-rates", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.SetRates)
+}
+
+func (h *exchangeRateHandler) GetRates(c *fiber.Ctx) error {
+	resp, err := h.service.GetRates(c.Context())
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *exchangeRateHandler) SetRates(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetExchangeRatesRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetRates - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetRates - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.SetRates(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Kurs berhasil disimpan"))
+}
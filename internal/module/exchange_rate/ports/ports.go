@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"codebase-app/internal/module/exchange_rate/entity"
+	"context"
+	"math/big"
+)
+
+type ExchangeRateRepository interface {
+	UpsertRates(ctx context.Context, rates []entity.ExchangeRate) error
+	GetRates(ctx context.Context) ([]entity.ExchangeRate, error)
+	GetPair(ctx context.Context, from, to string) ([]entity.ExchangeRate, error)
+}
+
+type ExchangeRateService interface {
+	SetRates(ctx context.Context, req *entity.SetExchangeRatesRequest) error
+	GetRates(ctx context.Context) (*entity.GetExchangeRatesResponse, error)
+	// Rate returns how many units of to one unit of from buys.
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"codebase-app/internal/module/exchange_rate/entity"
+	"codebase-app/internal/module/exchange_rate/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.ExchangeRateRepository = &exchangeRateRepository{}
+
+type exchangeRateRepository struct {
+	db *sqlx.DB
+}
+
+func NewExchangeRateRepository(db *sqlx.DB) *exchangeRateRepository {
+	return &exchangeRateRepository{
+		db: db,
+	}
+}
+
+func (r *exchangeRateRepository) UpsertRates(ctx context.Context, rates []entity.ExchangeRate) error {
+	query := `
+		INSERT INTO exchange_rates (base_currency, quote_currency, rate, updated_by)
+		VALUES (:base_currency, :quote_currency, CAST(:rate AS DECIMAL), :updated_by)
+		ON CONFLICT (base_currency, quote_currency) DO UPDATE
+		SET
+			rate = EXCLUDED.rate,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, rates)
+	if err != nil {
+		log.Error().Err(err).Int("rates", len(rates)).Msg("repository::UpsertRates - Failed to upsert exchange rates")
+		return err
+	}
+
+	return nil
+}
+
+func (r *exchangeRateRepository) GetRates(ctx context.Context) ([]entity.ExchangeRate, error) {
+	var rates = make([]entity.ExchangeRate, 0)
+
+	query := `
+		SELECT base_currency, quote_currency, rate::text as rate, updated_by, updated_at
+		FROM exchange_rates
+		ORDER BY base_currency, quote_currency
+	`
+
+	err := r.db.SelectContext(ctx, &rates, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::GetRates - Failed to get exchange rates")
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// GetPair returns the rate stored for from/to and for to/from, if any.
+func (r *exchangeRateRepository) GetPair(ctx context.Context, from, to string) ([]entity.ExchangeRate, error) {
+	var rates = make([]entity.ExchangeRate, 0, 2)
+
+	query := `
+		SELECT base_currency, quote_currency, rate::text as rate, updated_by, updated_at
+		FROM exchange_rates
+		WHERE (base_currency = ? AND quote_currency = ?) OR (base_currency = ? AND quote_currency = ?)
+	`
+
+	err := r.db.SelectContext(ctx, &rates, r.db.Rebind(query), from, to, to, from)
+	if err != nil {
+		log.Error().Err(err).Str("from", from).Str("to", to).Msg("repository::GetPair - Failed to get exchange rate")
+		return nil, err
+	}
+
+	return rates, nil
+}
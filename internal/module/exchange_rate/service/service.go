@@ -0,0 +1,146 @@
+package service
+
+import (
+	"codebase-app/internal/module/exchange_rate/entity"
+	"codebase-app/internal/module/exchange_rate/ports"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/types"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"path/filepath"
+	"strings"
+)
+
+var _ ports.ExchangeRateService = &exchangeRateService{}
+
+type exchangeRateService struct {
+	repo ports.ExchangeRateRepository
+}
+
+func NewExchangeRateService(repo ports.ExchangeRateRepository) *exchangeRateService {
+	return &exchangeRateService{
+		repo: repo,
+	}
+}
+
+// SetRates upserts the rates. The checks here also cover rates loaded from a
+// file, which do not go through the request validator.
+func (s *exchangeRateService) SetRates(ctx context.Context, req *entity.SetExchangeRatesRequest) error {
+	errs := errmsg.NewCustomErrors(400)
+
+	for i := range req.Rates {
+		var (
+			rate  = &req.Rates[i]
+			field = fmt.Sprintf("rates[%d]", i)
+		)
+
+		rate.BaseCurrency = strings.ToUpper(rate.BaseCurrency)
+		rate.QuoteCurrency = strings.ToUpper(rate.QuoteCurrency)
+		rate.UpdatedBy = req.UserId
+
+		if !types.IsValidCurrency(rate.BaseCurrency) {
+			errs.Add(field+".base_currency", "base currency bukan mata uang yang didukung.")
+		}
+		if !types.IsValidCurrency(rate.QuoteCurrency) {
+			errs.Add(field+".quote_currency", "quote currency bukan mata uang yang didukung.")
+		}
+		if rate.BaseCurrency == rate.QuoteCurrency {
+			errs.Add(field+".quote_currency", "quote currency harus berbeda dengan base currency.")
+		}
+		if r, ok := new(big.Rat).SetString(rate.Rate); !ok || r.Sign() <= 0 {
+			errs.Add(field+".rate", "rate harus angka lebih dari 0.")
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	return s.repo.UpsertRates(ctx, req.Rates)
+}
+
+func (s *exchangeRateService) GetRates(ctx context.Context) (*entity.GetExchangeRatesResponse, error) {
+	rates, err := s.repo.GetRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.GetExchangeRatesResponse{Items: rates}, nil
+}
+
+// Rate uses the stored from/to rate, or the inverse of to/from.
+func (s *exchangeRateService) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	rates, err := s.repo.GetPair(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var inverse *big.Rat
+	for _, rate := range rates {
+		r, ok := new(big.Rat).SetString(rate.Rate)
+		if !ok || r.Sign() <= 0 {
+			continue
+		}
+
+		if rate.BaseCurrency == from {
+			return r, nil
+		}
+		inverse = r.Inv(r)
+	}
+
+	if inverse == nil {
+		return nil, fmt.Errorf("%w: %s/%s", entity.ErrRateNotFound, from, to)
+	}
+
+	return inverse, nil
+}
+
+// ParseRatesFile reads rates from a JSON array of rates or, for any other
+// extension, a CSV file with base_currency, quote_currency and rate columns.
+func ParseRatesFile(filename string, r io.Reader) ([]entity.ExchangeRate, error) {
+	var rates []entity.ExchangeRate
+
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		if err := json.NewDecoder(r).Decode(&rates); err != nil {
+			return nil, err
+		}
+		return rates, nil
+	}
+
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return rates, nil
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"base_currency", "quote_currency", "rate"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	for _, record := range records[1:] {
+		rates = append(rates, entity.ExchangeRate{
+			BaseCurrency:  strings.TrimSpace(record[columns["base_currency"]]),
+			QuoteCurrency: strings.TrimSpace(record[columns["quote_currency"]]),
+			Rate:          strings.TrimSpace(record[columns["rate"]]),
+		})
+	}
+
+	return rates, nil
+}
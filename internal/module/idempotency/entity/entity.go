@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrKeyReused is returned when a key is sent again with a different
+	// request.
+	ErrKeyReused = errors.New("idempotency key reused with a different request")
+	// ErrInProgress is returned while the first request with the key is still
+	// running, or crashed less than its lease ago.
+	ErrInProgress = errors.New("idempotency key request in progress")
+)
+
+type ClaimRequest struct {
+	UserId      string
+	Key         string
+	RequestHash string
+	// TTL is how long the response is remembered, Lease how long the claim
+	// holds before a retry can take over a request that never completed.
+	TTL   time.Duration
+	Lease time.Duration
+}
+
+// ClaimResponse has the Id of the claimed key, or the Response stored for an
+// identical earlier request.
+type ClaimResponse struct {
+	Id       string
+	Response *StoredResponse
+}
+
+type StoredResponse struct {
+	RequestHash string `db:"request_hash"`
+	StatusCode  *int   `db:"status_code"`
+	Body        []byte `db:"response_body"`
+	ContentType string `db:"content_type"`
+}
+
+type CompleteRequest struct {
+	Id          string
+	StatusCode  int
+	Body        []byte
+	ContentType string
+}
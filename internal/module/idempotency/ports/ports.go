@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"codebase-app/internal/module/idempotency/entity"
+	"context"
+)
+
+type IdempotencyRepository interface {
+	ClaimKey(ctx context.Context, req *entity.ClaimRequest) (string, error)
+	GetResponse(ctx context.Context, userId, key string) (*entity.StoredResponse, error)
+	CompleteKey(ctx context.Context, req *entity.CompleteRequest) error
+	DeleteKey(ctx context.Context, id string) error
+	DeleteExpiredKeys(ctx context.Context) (int64, error)
+}
+
+type IdempotencyService interface {
+	Claim(ctx context.Context, req *entity.ClaimRequest) (*entity.ClaimResponse, error)
+	Complete(ctx context.Context, req *entity.CompleteRequest) error
+	Release(ctx context.Context, id string) error
+	Purge(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"codebase-app/internal/module/idempotency/entity"
+	"codebase-app/internal/module/idempotency/ports"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.IdempotencyRepository = &idempotencyRepository{}
+
+type idempotencyRepository struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyRepository(db *sqlx.DB) *idempotencyRepository {
+	return &idempotencyRepository{
+		db: db,
+	}
+}
+
+// ClaimKey claims the key and returns its id, or an empty id when the key is
+// held. An expired key is taken over, and so is a claim of the same request
+// whose lease ran out without a response, ex: the process crashed.
+func (r *idempotencyRepository) ClaimKey(ctx context.Context, req *entity.ClaimRequest) (string, error) {
+	var id string
+
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, request_hash, expires_at, locked_until)
+		VALUES (?, ?, ?, NOW() + make_interval(secs => ?), NOW() + make_interval(secs => ?))
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET
+			request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			response_body = NULL,
+			content_type = NULL,
+			created_at = NOW(),
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at,
+			locked_until = EXCLUDED.locked_until
+		WHERE
+			idempotency_keys.expires_at < NOW()
+			OR (
+				idempotency_keys.completed_at IS NULL
+				AND idempotency_keys.locked_until < NOW()
+				AND idempotency_keys.request_hash = EXCLUDED.request_hash
+			)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.UserId,
+		req.Key,
+		req.RequestHash,
+		req.TTL.Seconds(),
+		req.Lease.Seconds()).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		log.Error().Err(err).Str("key", req.Key).Msg("repository::ClaimKey - Failed to claim key")
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *idempotencyRepository) GetResponse(ctx context.Context, userId, key string) (*entity.StoredResponse, error) {
+	var resp = new(entity.StoredResponse)
+
+	query := `
+		SELECT request_hash, status_code, response_body, COALESCE(content_type, '') as content_type
+		FROM idempotency_keys
+		WHERE user_id = ? AND key = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), userId, key).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("repository::GetResponse - Failed to get stored response")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *idempotencyRepository) CompleteKey(ctx context.Context, req *entity.CompleteRequest) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = ?, response_body = ?, content_type = ?, completed_at = NOW(), locked_until = NULL
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.StatusCode, req.Body, req.ContentType, req.Id)
+	if err != nil {
+		log.Error().Err(err).Str("id", req.Id).Msg("repository::CompleteKey - Failed to store response")
+		return err
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteKey(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(`DELETE FROM idempotency_keys WHERE id = ?`), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::DeleteKey - Failed to delete key")
+		return err
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpiredKeys(ctx context.Context) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at < NOW()`)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::DeleteExpiredKeys - Failed to delete expired keys")
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"codebase-app/internal/module/idempotency/entity"
+	"codebase-app/internal/module/idempotency/ports"
+	"context"
+)
+
+var _ ports.IdempotencyService = &idempotencyService{}
+
+type idempotencyService struct {
+	repo ports.IdempotencyRepository
+}
+
+func NewIdempotencyService(repo ports.IdempotencyRepository) *idempotencyService {
+	return &idempotencyService{
+		repo: repo,
+	}
+}
+
+// Claim claims the key for the request, or returns the response stored for
+// an identical earlier request. It fails with ErrKeyReused when the key was
+// used for another request and ErrInProgress while that request still holds
+// its lease.
+func (s *idempotencyService) Claim(ctx context.Context, req *entity.ClaimRequest) (*entity.ClaimResponse, error) {
+	id, err := s.repo.ClaimKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if id != "" {
+		return &entity.ClaimResponse{Id: id}, nil
+	}
+
+	stored, err := s.repo.GetResponse(ctx, req.UserId, req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.RequestHash != req.RequestHash {
+		return nil, entity.ErrKeyReused
+	}
+	if stored.StatusCode == nil {
+		return nil, entity.ErrInProgress
+	}
+
+	return &entity.ClaimResponse{Response: stored}, nil
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, req *entity.CompleteRequest) error {
+	return s.repo.CompleteKey(ctx, req)
+}
+
+// Release forgets a claim so the client can retry, ex: after a server error.
+func (s *idempotencyService) Release(ctx context.Context, id string) error {
+	return s.repo.DeleteKey(ctx, id)
+}
+
+// Purge deletes the expired keys and returns how many were deleted. Expired
+// keys are only taken over when reused, so without a purge the table keeps
+// every key ever sent.
+func (s *idempotencyService) Purge(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpiredKeys(ctx)
+}
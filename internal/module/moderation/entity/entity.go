@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+const (
+	// RuleKindKeyword matches a whole word or phrase, ignoring case.
+	RuleKindKeyword = "keyword"
+	// RuleKindRegex matches a Go regular expression, ignoring case.
+	RuleKindRegex = "regex"
+
+	DecisionApproved = "approved"
+	DecisionRejected = "rejected"
+
+	QueueStatusFlagged  = "flagged"
+	QueueStatusRejected = "rejected"
+)
+
+// CreateRuleRequest bans a keyword or pattern in the products of a
+// category, or of every category when CategoryId is empty. Reason is what
+// the seller is told.
+type CreateRuleRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	CategoryId string `json:"category_id" validate:"omitempty,uuid"`
+	Kind       string `json:"kind" validate:"required,oneof=keyword regex"`
+	Pattern    string `json:"pattern" validate:"required,max=200"`
+	Reason     string `json:"reason" validate:"required,max=255"`
+}
+
+type RuleItem struct {
+	Id         string    `json:"id" db:"id"`
+	CategoryId *string   `json:"category_id" db:"category_id"`
+	Kind       string    `json:"kind" db:"kind"`
+	Pattern    string    `json:"pattern" db:"pattern"`
+	Reason     string    `json:"reason" db:"reason"`
+	CreatedBy  string    `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+type GetRulesRequest struct {
+	CategoryId string `query:"category_id" validate:"omitempty,uuid"`
+
+	Page     int `query:"page" validate:"required"`
+	Paginate int `query:"paginate" validate:"required"`
+}
+
+func (r *GetRulesRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type GetRulesResponse struct {
+	Items []RuleItem `json:"items"`
+	Meta  types.Meta `json:"meta"`
+}
+
+type DeleteRuleRequest struct {
+	Id string `validate:"uuid"`
+}
+
+// GetQueueRequest lists the flagged products waiting for a review, oldest
+// first, or the rejected ones.
+type GetQueueRequest struct {
+	Status     string `query:"status" validate:"oneof=flagged rejected"`
+	CategoryId string `query:"category_id" validate:"omitempty,uuid"`
+
+	Page     int `query:"page" validate:"required"`
+	Paginate int `query:"paginate" validate:"required"`
+}
+
+func (r *GetQueueRequest) SetDefault() {
+	if r.Status == "" {
+		r.Status = QueueStatusFlagged
+	}
+
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type QueueItem struct {
+	Id           string     `json:"id" db:"id"`
+	Name         string     `json:"name" db:"name"`
+	Description  string     `json:"description" db:"description"`
+	Status       string     `json:"status" db:"status"`
+	Reasons      []string   `json:"reasons" db:"-"`
+	CategoryId   string     `json:"category_id" db:"category_id"`
+	CategoryName string     `json:"category_name" db:"category_name"`
+	ShopId       string     `json:"shop_id" db:"shop_id"`
+	ShopName     string     `json:"shop_name" db:"shop_name"`
+	FlaggedAt    *time.Time `json:"flagged_at" db:"moderation_flagged_at"`
+	UpdatedAt    *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type GetQueueResponse struct {
+	Items []QueueItem `json:"items"`
+	Meta  types.Meta  `json:"meta"`
+}
+
+// DecideRequest approves or rejects a flagged product. An approval restores
+// the status the product had before it was flagged. Note is sent to the
+// seller and is required for a rejection.
+type DecideRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id       string `validate:"uuid"`
+	Decision string `json:"-" validate:"oneof=approved rejected"`
+	Note     string `json:"note" validate:"required_if=Decision rejected,max=500"`
+}
+
+// DecidedProduct is a product after the moderator decision, with the owner
+// to notify.
+type DecidedProduct struct {
+	Id         string   `json:"id" db:"id"`
+	Name       string   `json:"name" db:"name"`
+	Status     string   `json:"status" db:"status"`
+	Decision   string   `json:"decision" db:"decision"`
+	Reasons    []string `json:"reasons" db:"-"`
+	Note       string   `json:"note" db:"note"`
+	ShopUserId string   `json:"-" db:"shop_user_id"`
+}
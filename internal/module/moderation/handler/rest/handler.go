@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/moderation/entity"
+	"codebase-app/internal/module/moderation/ports"
+	"codebase-app/internal/module/moderation/repository"
+	"codebase-app/internal/module/moderation/service"
+	notificationRepository "codebase-app/internal/module/notification/repository"
+	notificationService "codebase-app/internal/module/notification/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type moderationHandler struct {
+	service ports.ModerationService
+}
+
+func NewModerationHandler() *moderationHandler {
+	var (
+		handler      = new(moderationHandler)
+		repo         = repository.NewModerationRepository(adapter.Adapters.ShopeefunPostgres)
+		notification = notificationService.NewNotificationService(notificationRepository.NewNotificationRepository(adapter.Adapters.ShopeefunPostgres))
+		service      = service.NewModerationService(repo, notification)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *moderationHandler) Register(router fiber.Router) {
+	router.Post("/moderation/rules", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.CreateRule)
+	router.Get("/moderation/rules", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.GetRules)
+	router.Delete("/moderation/rules/:id", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.DeleteRule)
+	router.Get("/moderation/queue", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.GetQueue)
+	router.Post("/moderation/queue/:id/approve", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.ApproveProduct)
+	router.Post("/moderation/queue/:id/reject", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.RejectProduct)
+}
+
+func (h *moderationHandler) CreateRule(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateRuleRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateRule - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateRule - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateRule(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, "Aturan moderasi berhasil dibuat"))
+}
+
+func (h *moderationHandler) GetRules(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetRulesRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetRules - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetRules - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetRules(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *moderationHandler) DeleteRule(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteRuleRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteRule - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.DeleteRule(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Aturan moderasi berhasil dihapus"))
+}
+
+func (h *moderationHandler) GetQueue(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetQueueRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetQueue - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetQueue - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetQueue(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *moderationHandler) ApproveProduct(c *fiber.Ctx) error {
+	return h.decide(c, entity.DecisionApproved)
+}
+
+func (h *moderationHandler) RejectProduct(c *fiber.Ctx) error {
+	return h.decide(c, entity.DecisionRejected)
+}
+
+// decide approves or rejects a queued product. The body with the note is
+// optional for an approval.
+func (h *moderationHandler) decide(c *fiber.Ctx, decision string) error {
+	var (
+		req = new(entity.DecideRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(req); err != nil {
+			log.Warn().Err(err).Msg("handler::Decide - Parse request body")
+			return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+		}
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+	req.Decision = decision
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::Decide - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.Decide(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	msg := "Produk berhasil disetujui"
+	if decision == entity.DecisionRejected {
+		msg = "Produk berhasil ditolak"
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, msg))
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"codebase-app/internal/module/moderation/entity"
+	productEntity "codebase-app/internal/module/product/entity"
+	"context"
+)
+
+type ModerationRepository interface {
+	CreateRule(ctx context.Context, req *entity.CreateRuleRequest) (*entity.RuleItem, error)
+	GetRules(ctx context.Context, req *entity.GetRulesRequest) (*entity.GetRulesResponse, error)
+	GetScreeningRules(ctx context.Context, categoryId string) ([]entity.RuleItem, error)
+	DeleteRule(ctx context.Context, id string) error
+	GetQueue(ctx context.Context, req *entity.GetQueueRequest) (*entity.GetQueueResponse, error)
+	Decide(ctx context.Context, req *entity.DecideRequest) (*entity.DecidedProduct, error)
+}
+
+type ModerationService interface {
+	CreateRule(ctx context.Context, req *entity.CreateRuleRequest) (*entity.RuleItem, error)
+	GetRules(ctx context.Context, req *entity.GetRulesRequest) (*entity.GetRulesResponse, error)
+	DeleteRule(ctx context.Context, req *entity.DeleteRuleRequest) error
+	Screen(ctx context.Context, content *productEntity.ModerationContent) ([]string, error)
+	GetQueue(ctx context.Context, req *entity.GetQueueRequest) (*entity.GetQueueResponse, error)
+	Decide(ctx context.Context, req *entity.DecideRequest) (*entity.DecidedProduct, error)
+}
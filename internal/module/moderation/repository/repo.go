@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"codebase-app/internal/module/moderation/entity"
+	"codebase-app/internal/module/moderation/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.ModerationRepository = &moderationRepository{}
+
+const ruleColumns = `id, category_id, kind, pattern, reason, created_by, created_at`
+
+type moderationRepository struct {
+	db *sqlx.DB
+}
+
+func NewModerationRepository(db *sqlx.DB) *moderationRepository {
+	return &moderationRepository{
+		db: db,
+	}
+}
+
+func (r *moderationRepository) CreateRule(ctx context.Context, req *entity.CreateRuleRequest) (*entity.RuleItem, error) {
+	var resp = new(entity.RuleItem)
+
+	query := `
+		INSERT INTO moderation_rules (category_id, kind, pattern, reason, created_by)
+		VALUES (NULLIF(?, '')::uuid, ?, ?, ?, ?)
+		RETURNING ` + ruleColumns
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.CategoryId, req.Kind, req.Pattern, req.Reason, req.UserId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateRule - Failed to create rule")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetRules lists the live rules, newest first. A category filter includes
+// the rules of every category, which apply to it too.
+func (r *moderationRepository) GetRules(ctx context.Context, req *entity.GetRulesRequest) (*entity.GetRulesResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.RuleItem
+	}
+
+	var (
+		resp = new(entity.GetRulesResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.RuleItem, 0, req.Paginate)
+
+	query := `
+		SELECT COUNT(id) OVER() as total_data, ` + ruleColumns + `
+		FROM moderation_rules
+		WHERE deleted_at IS NULL
+			AND (? = '' OR category_id IS NULL OR category_id = NULLIF(?, '')::uuid)
+		ORDER BY created_at DESC, id
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), req.CategoryId, req.CategoryId, req.Paginate, (req.Page-1)*req.Paginate)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetRules - Failed to get rules")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.RuleItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// GetScreeningRules returns every live rule that applies to the category,
+// oldest first.
+func (r *moderationRepository) GetScreeningRules(ctx context.Context, categoryId string) ([]entity.RuleItem, error) {
+	var resp = make([]entity.RuleItem, 0)
+
+	query := `
+		SELECT ` + ruleColumns + `
+		FROM moderation_rules
+		WHERE deleted_at IS NULL AND (category_id IS NULL OR category_id = ?)
+		ORDER BY created_at, id
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), categoryId)
+	if err != nil {
+		log.Error().Err(err).Str("category_id", categoryId).Msg("repository::GetScreeningRules - Failed to get rules")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *moderationRepository) DeleteRule(ctx context.Context, id string) error {
+	query := `UPDATE moderation_rules SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL RETURNING id`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).Scan(&id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::DeleteRule - Failed to delete rule")
+		return err
+	}
+
+	return nil
+}
+
+// GetQueue lists the products in a moderation status, the longest waiting
+// first.
+func (r *moderationRepository) GetQueue(ctx context.Context, req *entity.GetQueueRequest) (*entity.GetQueueResponse, error) {
+	type dao struct {
+		TotalData int            `db:"total_data"`
+		Reasons   pq.StringArray `db:"moderation_reasons"`
+		entity.QueueItem
+	}
+
+	var (
+		resp = new(entity.GetQueueResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.QueueItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(p.id) OVER() as total_data,
+			p.id,
+			p.name,
+			COALESCE(p.description, '') as description,
+			p.status,
+			p.moderation_reasons,
+			p.category_id,
+			c.name as category_name,
+			p.shop_id,
+			s.name as shop_name,
+			p.moderation_flagged_at,
+			p.updated_at
+		FROM product p
+		JOIN category c ON c.id = p.category_id
+		JOIN shops s ON s.id = p.shop_id
+		WHERE p.status = ? AND p.deleted_at IS NULL
+			AND (? = '' OR p.category_id = NULLIF(?, '')::uuid)
+		ORDER BY p.moderation_flagged_at NULLS LAST, p.updated_at, p.id
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.Status,
+		req.CategoryId,
+		req.CategoryId,
+		req.Paginate,
+		(req.Page-1)*req.Paginate)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetQueue - Failed to get queue")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		d.QueueItem.Reasons = []string(d.Reasons)
+		resp.Items = append(resp.Items, d.QueueItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// Decide takes a flagged product out of the queue and records the decision
+// with the reasons it was flagged for. It returns sql.ErrNoRows when the
+// product is not flagged, so two moderators cannot decide it both.
+func (r *moderationRepository) Decide(ctx context.Context, req *entity.DecideRequest) (*entity.DecidedProduct, error) {
+	var (
+		resp    = new(entity.DecidedProduct)
+		reasons pq.StringArray
+		query   = `
+			WITH previous AS (
+				SELECT id, moderation_reasons
+				FROM product
+				WHERE id = ? AND status = 'flagged' AND deleted_at IS NULL
+				FOR UPDATE
+			), decided AS (
+				UPDATE product p
+				SET status = CASE WHEN ? = 'approved' THEN COALESCE(p.moderation_previous_status, 'active') ELSE 'rejected' END,
+					moderation_previous_status = CASE WHEN ? = 'approved' THEN NULL ELSE p.moderation_previous_status END,
+					moderation_reasons = CASE WHEN ? = 'approved' THEN '{}' ELSE p.moderation_reasons END,
+					moderation_flagged_at = NULL,
+					updated_at = NOW()
+				FROM previous pr
+				WHERE p.id = pr.id
+				RETURNING p.id, p.name, p.status, p.shop_id
+			), history AS (
+				INSERT INTO product_moderations (product_id, decision, reasons, note, reviewed_by)
+				SELECT pr.id, ?, pr.moderation_reasons, NULLIF(?, ''), ?
+				FROM previous pr
+				JOIN decided d ON d.id = pr.id
+			)
+			SELECT d.id, d.name, d.status, pr.moderation_reasons, s.user_id
+			FROM decided d
+			JOIN previous pr ON pr.id = d.id
+			JOIN shops s ON s.id = d.shop_id`
+	)
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Id,
+		req.Decision,
+		req.Decision,
+		req.Decision,
+		req.Decision,
+		req.Note,
+		req.UserId).Scan(&resp.Id, &resp.Name, &resp.Status, &reasons, &resp.ShopUserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::Decide - Failed to decide product")
+		return nil, err
+	}
+	resp.Decision = req.Decision
+	resp.Reasons = []string(reasons)
+	resp.Note = req.Note
+
+	return resp, nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"codebase-app/internal/module/moderation/entity"
+	"codebase-app/internal/module/moderation/ports"
+	notificationEntity "codebase-app/internal/module/notification/entity"
+	notificationPorts "codebase-app/internal/module/notification/ports"
+	productEntity "codebase-app/internal/module/product/entity"
+	productPorts "codebase-app/internal/module/product/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	_ ports.ModerationService       = &moderationService{}
+	_ productPorts.ProductModerator = &moderationService{}
+)
+
+type moderationService struct {
+	repo         ports.ModerationRepository
+	notification notificationPorts.NotificationService
+}
+
+func NewModerationService(repo ports.ModerationRepository, notification notificationPorts.NotificationService) *moderationService {
+	return &moderationService{
+		repo:         repo,
+		notification: notification,
+	}
+}
+
+func (s *moderationService) CreateRule(ctx context.Context, req *entity.CreateRuleRequest) (*entity.RuleItem, error) {
+	req.Pattern = strings.TrimSpace(req.Pattern)
+
+	if _, err := compileRule(req.Kind, req.Pattern); err != nil {
+		errs := errmsg.NewCustomErrors(422, errmsg.WithMessage("Aturan moderasi tidak valid"))
+		if req.Kind == entity.RuleKindRegex {
+			errs.Add("pattern", "pattern bukan regular expression yang valid.")
+		} else {
+			errs.Add("pattern", "pattern harus berisi minimal satu kata.")
+		}
+		return nil, errs
+	}
+
+	return s.repo.CreateRule(ctx, req)
+}
+
+func (s *moderationService) GetRules(ctx context.Context, req *entity.GetRulesRequest) (*entity.GetRulesResponse, error) {
+	return s.repo.GetRules(ctx, req)
+}
+
+func (s *moderationService) DeleteRule(ctx context.Context, req *entity.DeleteRuleRequest) error {
+	err := s.repo.DeleteRule(ctx, req.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Aturan moderasi tidak ditemukan"))
+	}
+
+	return err
+}
+
+// Screen matches the name and description of a product against the rules
+// of its category and the global ones. It returns the reasons of the rules
+// that matched, each once.
+func (s *moderationService) Screen(ctx context.Context, content *productEntity.ModerationContent) ([]string, error) {
+	rules, err := s.repo.GetScreeningRules(ctx, content.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		text    = content.Name + "\n" + content.Description
+		reasons []string
+	)
+
+	for _, rule := range rules {
+		re, err := compileRule(rule.Kind, rule.Pattern)
+		if err != nil {
+			// rules are validated when created, a broken one must not stop
+			// sellers from saving their products
+			log.Warn().Err(err).Str("rule_id", rule.Id).Msg("service::Screen - Skip invalid rule")
+			continue
+		}
+
+		if re.MatchString(text) && !slices.Contains(reasons, rule.Reason) {
+			reasons = append(reasons, rule.Reason)
+		}
+	}
+
+	return reasons, nil
+}
+
+func (s *moderationService) GetQueue(ctx context.Context, req *entity.GetQueueRequest) (*entity.GetQueueResponse, error) {
+	return s.repo.GetQueue(ctx, req)
+}
+
+// Decide approves or rejects a flagged product and tells the seller.
+func (s *moderationService) Decide(ctx context.Context, req *entity.DecideRequest) (*entity.DecidedProduct, error) {
+	resp, err := s.repo.Decide(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan dalam antrean moderasi"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	notify := &notificationEntity.NotifyRequest{
+		UserId: resp.ShopUserId,
+		Body:   resp.Note,
+		Data:   map[string]any{"product_id": resp.Id, "reasons": resp.Reasons},
+	}
+	if resp.Decision == entity.DecisionApproved {
+		notify.Type = notificationEntity.TypeProductApproved
+		notify.Title = "Produk " + resp.Name + " lolos moderasi"
+		if notify.Body == "" {
+			notify.Body = "Produk Anda telah ditinjau dan dapat ditampilkan kembali."
+		}
+	} else {
+		notify.Type = notificationEntity.TypeProductRejected
+		notify.Title = "Produk " + resp.Name + " ditolak"
+	}
+	s.notification.Notify(ctx, notify)
+
+	return resp, nil
+}
+
+// compileRule returns the case insensitive regular expression of a rule. A
+// keyword matches whole words only, with any whitespace between its words,
+// so "obat keras" matches "Obat  Keras" but not "obatkeras".
+func compileRule(kind, pattern string) (*regexp.Regexp, error) {
+	if kind == entity.RuleKindRegex {
+		return regexp.Compile("(?i)" + pattern)
+	}
+
+	words := strings.Fields(pattern)
+	if len(words) == 0 {
+		return nil, errors.New("empty keyword")
+	}
+	for i, w := range words {
+		words[i] = regexp.QuoteMeta(w)
+	}
+	expr := strings.Join(words, `\s+`)
+
+	// \b only sits between a word and a non word character
+	if r, _ := utf8.DecodeRuneInString(pattern); isWordRune(r) {
+		expr = `\b` + expr
+	}
+	if r, _ := utf8.DecodeLastRuneInString(pattern); isWordRune(r) {
+		expr += `\b`
+	}
+
+	return regexp.Compile("(?i)" + expr)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
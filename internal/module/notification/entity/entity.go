@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"encoding/json"
+	"time"
+)
+
+const (
+	TypeProductQuestion = "product_question"
+	TypeProductAnswer   = "product_answer"
+	TypeProductApproved = "product_approved"
+	TypeProductRejected = "product_rejected"
+)
+
+// NotifyRequest is what other modules hand to the notification service.
+// Data must marshal to a JSON object and tells the client what to open.
+type NotifyRequest struct {
+	UserId string
+	Type   string
+	Title  string
+	Body   string
+	Data   any
+}
+
+type CreateNotificationRequest struct {
+	UserId string          `db:"user_id"`
+	Type   string          `db:"type"`
+	Title  string          `db:"title"`
+	Body   string          `db:"body"`
+	Data   json.RawMessage `db:"data"`
+}
+
+type NotificationItem struct {
+	Id        string          `json:"id" db:"id"`
+	Type      string          `json:"type" db:"type"`
+	Title     string          `json:"title" db:"title"`
+	Body      string          `json:"body" db:"body"`
+	Data      json.RawMessage `json:"data" db:"data"`
+	ReadAt    *time.Time      `json:"read_at" db:"read_at"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+type GetNotificationsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Unread   bool `query:"unread"`
+	Page     int  `query:"page" validate:"required"`
+	Paginate int  `query:"paginate" validate:"required"`
+}
+
+func (r *GetNotificationsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type GetNotificationsResponse struct {
+	Items []NotificationItem `json:"items"`
+	Meta  types.Meta         `json:"meta"`
+}
+
+type ReadNotificationRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+}
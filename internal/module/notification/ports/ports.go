@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"codebase-app/internal/module/notification/entity"
+	"context"
+)
+
+type NotificationRepository interface {
+	CreateNotification(ctx context.Context, req *entity.CreateNotificationRequest) error
+	GetNotifications(ctx context.Context, req *entity.GetNotificationsRequest) (*entity.GetNotificationsResponse, error)
+	ReadNotification(ctx context.Context, req *entity.ReadNotificationRequest) error
+}
+
+type NotificationService interface {
+	Notify(ctx context.Context, req *entity.NotifyRequest)
+	GetNotifications(ctx context.Context, req *entity.GetNotificationsRequest) (*entity.GetNotificationsResponse, error)
+	ReadNotification(ctx context.Context, req *entity.ReadNotificationRequest) error
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"codebase-app/internal/module/notification/entity"
+	"codebase-app/internal/module/notification/ports"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.NotificationRepository = &notificationRepository{}
+
+type notificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) *notificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (r *notificationRepository) CreateNotification(ctx context.Context, req *entity.CreateNotificationRequest) error {
+	query := `
+		INSERT INTO notifications (user_id, type, title, body, data)
+		VALUES (?, ?, ?, ?, ?::jsonb)
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.UserId, req.Type, req.Title, req.Body, string(req.Data))
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateNotification - Failed to create notification")
+		return err
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) GetNotifications(ctx context.Context, req *entity.GetNotificationsRequest) (*entity.GetNotificationsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.NotificationItem
+	}
+
+	var (
+		resp = new(entity.GetNotificationsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.NotificationItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			type,
+			title,
+			body,
+			data,
+			read_at,
+			created_at
+		FROM notifications
+		WHERE user_id = ? AND (? = false OR read_at IS NULL)
+		ORDER BY created_at DESC, id
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), req.UserId, req.Unread, req.Paginate, (req.Page-1)*req.Paginate)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetNotifications - Failed to get notifications")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.NotificationItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// ReadNotification returns sql.ErrNoRows when the notification does not
+// belong to the user.
+func (r *notificationRepository) ReadNotification(ctx context.Context, req *entity.ReadNotificationRequest) error {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id = ? AND user_id = ?
+	`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReadNotification - Failed to read notification")
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"codebase-app/internal/module/notification/entity"
+	"codebase-app/internal/module/notification/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.NotificationService = &notificationService{}
+
+type notificationService struct {
+	repo ports.NotificationRepository
+}
+
+func NewNotificationService(repo ports.NotificationRepository) *notificationService {
+	return &notificationService{
+		repo: repo,
+	}
+}
+
+// Notify stores a notification for the user. Like the audit log, a failure
+// is logged but never fails the action that triggered it.
+func (s *notificationService) Notify(ctx context.Context, req *entity.NotifyRequest) {
+	log := log.With().Str("user_id", req.UserId).Str("type", req.Type).Logger()
+
+	data := json.RawMessage("{}")
+	if req.Data != nil {
+		b, err := json.Marshal(req.Data)
+		if err != nil {
+			log.Error().Err(err).Msg("service::Notify - Failed to marshal data")
+			return
+		}
+		data = b
+	}
+
+	err := s.repo.CreateNotification(ctx, &entity.CreateNotificationRequest{
+		UserId: req.UserId,
+		Type:   req.Type,
+		Title:  req.Title,
+		Body:   req.Body,
+		Data:   data,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("service::Notify - Failed to store notification")
+	}
+}
+
+func (s *notificationService) GetNotifications(ctx context.Context, req *entity.GetNotificationsRequest) (*entity.GetNotificationsResponse, error) {
+	return s.repo.GetNotifications(ctx, req)
+}
+
+func (s *notificationService) ReadNotification(ctx context.Context, req *entity.ReadNotificationRequest) error {
+	err := s.repo.ReadNotification(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Notifikasi tidak ditemukan"))
+	}
+
+	return err
+}
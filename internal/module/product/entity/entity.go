@@ -1,35 +1,117 @@
 package entity
 
-import "codebase-app/pkg/types"
+import (
+	"codebase-app/pkg/types"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type CreateProductRequest struct {
 	UserId string `validate:"uuid" db:"user_id"`
 
 	Name        string  `json:"name" validate:"required,min=3,max=100" db:"name"`
 	Brand	   	string  `json:"brand" validate:"required,min=3" db:"brand"`
-	Price       float64 `json:"price" validate:"required" db:"price"`
-	Stock       int     `json:"stock" validate:"required,min=1" db:"stock"`
+	Price       types.Money `json:"price" validate:"required,gt=0" db:"price"`
+	// Stock is only set for a single product, see BundleItems.
+	Stock       int     `json:"stock" validate:"required_without=BundleItems,omitempty,min=1" db:"stock"`
 	CategoryId  string  `json:"category_id" validate:"required,uuid" db:"category_id"`
 	ShopId      string  `json:"shop_id" validate:"required,uuid" db:"shop_id"`
-	Description string  `json:"description" db:"description"`
+	Description string  `json:"description" validate:"max=20000" db:"description"`
+	DescriptionFormat string `json:"description_format" validate:"omitempty,oneof=plain markdown html" db:"description_format"`
+	// BundleItems make the product a bundle of other products of the shop.
+	// Its stock is derived from theirs.
+	BundleItems []BundleItem `json:"bundle_items" validate:"omitempty,min=2,max=10,dive" db:"-"`
+	// Type is set by the service from BundleItems.
+	Type string `json:"-" db:"type"`
+	// DescriptionHtml and DescriptionExcerpt are rendered from Description
+	// by the service.
+	DescriptionHtml    string `json:"-" db:"description_html"`
+	DescriptionExcerpt string `json:"-" db:"description_excerpt"`
 	ImageUrl    string  `json:"image_url" db:"image_url"`
+	Tags        []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50" db:"-"`
+	// Slug is made from Name by the service, see pkg.Slugify.
+	Slug string `json:"-" db:"slug"`
+	// ModerationReasons are the banned keywords the service found in the
+	// name or description. The product is then flagged for a review.
+	ModerationReasons []string `json:"-" db:"-"`
 }
 
 type CreateProductResponse struct {
-	Id string `json:"id" db:"id"`
+	Id     string `json:"id" db:"id"`
+	Status string `json:"status" db:"status"`
+	// DuplicateOf is the product it was flagged a duplicate of.
+	DuplicateOf *string `json:"duplicate_of,omitempty" db:"-"`
+	// ModerationReasons tell the seller why a flagged product waits for a
+	// review.
+	ModerationReasons []string `json:"moderation_reasons,omitempty" db:"-"`
 }
 
 type GetProductDetailRequest struct {
+	// UserId is the optional caller; only the shop owner sees a product that
+	// is not active.
+	UserId string `prop:"user_id" validate:"omitempty,uuid"`
+
 	Id string `validate:"uuid" db:"id"`
+
+	// Currency converts the prices for display, see ConvertedPrice.
+	Currency string `query:"currency" validate:"omitempty,currency"`
+	// Locales are the translations to show, best first, see
+	// types.PreferredLocales.
+	Locales []string `query:"-"`
+}
+
+// GetProductBySlugRequest looks a product of a shop up by its slug. A
+// previous slug of a renamed product finds it too; the response then has
+// another Slug, which is the one to redirect to.
+type GetProductBySlugRequest struct {
+	UserId string `prop:"user_id" validate:"omitempty,uuid"`
+
+	ShopId string `validate:"uuid"`
+	Slug   string `validate:"required,max=120"`
+
+	Currency string   `query:"currency" validate:"omitempty,currency"`
+	Locales  []string `query:"-"`
 }
 
 type GetProductDetailResponse struct {
 	Id          string  `json:"id" db:"id"`
 	Name        string  `json:"name" db:"name"`
-	Price       float64 `json:"price" db:"price"`
+	Slug        string  `json:"slug" db:"slug"`
+	// Locale is the translation name and description are in, empty for the
+	// original text.
+	Locale      string  `json:"locale" db:"locale"`
+	Price       types.Money `json:"price" db:"price"`
+	EffectivePrice  types.Money `json:"effective_price" db:"effective_price"`
+	DiscountPercent int         `json:"discount_percent" db:"-"`
+	SaleEndsAt      *time.Time  `json:"sale_ends_at" db:"sale_ends_at"`
+	FlashSale       *FlashSaleItem `json:"flash_sale" db:"-"`
+	Currency        string          `json:"currency" db:"currency"`
+	ConvertedPrice  *ConvertedPrice `json:"converted_price" db:"-"`
+	LowestPrice30Days types.Money `json:"lowest_price_30d" db:"lowest_price_30d"`
+	PriceTiers  []PriceTier `json:"price_tiers" db:"-"`
+	TaxClass         *TaxClassItem   `json:"tax_class" db:"-"`
+	PricesIncludeTax bool            `json:"prices_include_tax" db:"prices_include_tax"`
+	PriceBreakdown   *PriceBreakdown `json:"price_breakdown" db:"-"`
+	Specifications   []Specification `json:"specifications" db:"-"`
+	Tags             []string        `json:"tags" db:"-"`
+	Rating           RatingSummary   `json:"rating" db:"-"`
 	Stock       int     `json:"stock" db:"stock"`
+	Status      string  `json:"status" db:"status"`
+	Type        string  `json:"type" db:"type"`
+	// BundleItems are the components of a bundle, nil for other products.
+	BundleItems []BundleComponent `json:"bundle_items,omitempty" db:"-"`
 	Category  CategoryItem  `json:"category"`
+	// Description is the source the seller wrote, DescriptionHtml the
+	// sanitized rendering to show.
 	Description *string  `json:"description" db:"description"`
+	DescriptionFormat string `json:"description_format" db:"description_format"`
+	DescriptionHtml   string `json:"description_html" db:"description_html"`
 	ImageUrl    *string  `json:"image_url" db:"image_url"`
 	Shop ShopItem `json:"shop"`
 }
@@ -45,16 +127,33 @@ type UpdateProductRequest struct {
 	Id          string  `params:"id" validate:"uuid" db:"id"`
 	Name        string  `json:"name" validate:"required,min=3,max=100" db:"name"`
 	Brand	   	string  `json:"brand" validate:"required,min=3" db:"brand"`
-	Price       float64 `json:"price" validate:"required" db:"price"`
-	Stock       int     `json:"stock" validate:"required,min=1" db:"stock"`
+	Price       types.Money `json:"price" validate:"required,gt=0" db:"price"`
+	// Stock is required unless the product is a bundle, whose stock is
+	// derived from its components and cannot be set.
+	Stock       int     `json:"stock" validate:"omitempty,min=1" db:"stock"`
 	CategoryId  string  `json:"category_id" validate:"required,uuid" db:"category_id"`
 	ShopId      string  `json:"shop_id" validate:"required,uuid" db:"shop_id"`
-	Description string  `json:"description" db:"description"`
+	Description string  `json:"description" validate:"max=20000" db:"description"`
+	DescriptionFormat string `json:"description_format" validate:"omitempty,oneof=plain markdown html" db:"description_format"`
+	DescriptionHtml    string `json:"-" db:"description_html"`
+	DescriptionExcerpt string `json:"-" db:"description_excerpt"`
 	ImageUrl    string  `json:"image_url" db:"image_url"`
+	// Tags replaces the product tags when present; omit it to keep them.
+	Tags        []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50" db:"-"`
+	// Slug is made from Name by the service. It is only regenerated when
+	// the name changes.
+	Slug string `json:"-" db:"slug"`
+	// ModerationReasons flag the product for a review, see
+	// CreateProductRequest. A rejected product is flagged again by any
+	// update.
+	ModerationReasons []string `json:"-" db:"-"`
 }
 
 type UpdateProductResponse struct {
-	Id string `json:"id" db:"id"`
+	Id          string  `json:"id" db:"id"`
+	Status      string  `json:"status" db:"status"`
+	DuplicateOf *string `json:"duplicate_of,omitempty" db:"-"`
+	ModerationReasons []string `json:"moderation_reasons,omitempty" db:"-"`
 }
 
 type DeleteProductRequest struct {
@@ -72,8 +171,15 @@ type GetProductsRequest struct {
 	ProductName string `query:"name"`
 	Brand string `query:"brand"`
 	CategoryId string `query:"category"`
-	MinPrice float64 `query:"min_price"`
-	MaxPrice float64 `query:"max_price"`
+	MinPrice types.Money `query:"min_price"`
+	MaxPrice types.Money `query:"max_price"`
+	Sort     string      `query:"sort" validate:"omitempty,oneof=newest price_asc price_desc"`
+	Currency string      `query:"currency" validate:"omitempty,currency"`
+	// Attributes is parsed from repeated attr[key]=value parameters by the
+	// handler: values of one key match any, different keys must all match.
+	Attributes []AttributeFilter `query:"-" validate:"max=10,dive"`
+	Tag        string            `query:"tag" validate:"omitempty,max=50"`
+	Locales    []string          `query:"-"`
 
 	Page     int    `query:"page" validate:"required"`
 	Paginate int    `query:"paginate" validate:"required"`
@@ -92,12 +198,24 @@ func (r *GetProductsRequest) SetDefault() {
 type ProductItem struct {
 	Id          string  `params:"id" validate:"uuid" db:"id"`
 	Name        string  `json:"name" validate:"required,min=3,max=100" db:"name"`
+	Slug        string  `json:"slug" db:"slug"`
+	Locale      string  `json:"locale" db:"locale"`
 	Brand	   	string  `json:"brand" db:"brand"`
-	Price       float64 `json:"price" validate:"required" db:"price"`
+	Price       types.Money `json:"price" validate:"required,gt=0" db:"price"`
+	EffectivePrice  types.Money `json:"effective_price" db:"effective_price"`
+	DiscountPercent int         `json:"discount_percent" db:"-"`
+	SaleEndsAt      *time.Time  `json:"sale_ends_at" db:"sale_ends_at"`
+	FlashSale       *FlashSaleItem `json:"flash_sale" db:"-"`
+	Currency        string          `json:"currency" db:"currency"`
+	ConvertedPrice  *ConvertedPrice `json:"converted_price" db:"-"`
+	Rating          RatingSummary   `json:"rating" db:"-"`
 	Stock       int     `json:"stock" validate:"required,min=1" db:"stock"`
+	Status      string  `json:"status" db:"status"`
+	Type        string  `json:"type" db:"type"`
 	CategoryId  string  `json:"category_id" validate:"required,uuid" db:"category_id"`
 	ShopId      string  `json:"shop_id" validate:"required,uuid" db:"shop_id"`
 	Description string  `json:"description" db:"description"`
+	DescriptionExcerpt string `json:"description_excerpt" db:"description_excerpt"`
 	ImageUrl    string  `json:"image_url" db:"image_url"`
 }
 
@@ -106,7 +224,776 @@ type GetProductsResponse struct {
 	Meta  types.Meta    `json:"meta"`
 }
 
+// FlashSaleItem is the running flash sale campaign a product is enrolled in,
+// nil when there is none or its quota is used up.
+type FlashSaleItem struct {
+	CampaignId     string      `json:"campaign_id"`
+	Name           string      `json:"name"`
+	Price          types.Money `json:"price"`
+	QuotaRemaining int         `json:"quota_remaining"`
+	EndsAt         time.Time   `json:"ends_at"`
+}
+
+// RatingSummary aggregates the published reviews of a product.
+// Distribution maps each star rating, "1" to "5", to its number of reviews.
+type RatingSummary struct {
+	Average      float64        `json:"average"`
+	Count        int            `json:"count"`
+	Distribution map[string]int `json:"distribution"`
+}
+
+// NewRatingSummary builds the summary from the stored aggregates; counts
+// holds the number of 1 to 5 star reviews. Average is rounded to one decimal.
+func NewRatingSummary(count, sum int, counts [5]int) RatingSummary {
+	resp := RatingSummary{Count: count, Distribution: make(map[string]int, len(counts))}
+
+	for i, n := range counts {
+		resp.Distribution[strconv.Itoa(i+1)] = n
+	}
+	if count > 0 {
+		resp.Average = math.Round(float64(sum)*10/float64(count)) / 10
+	}
+
+	return resp
+}
+
+// ConvertedPrice is the price in the currency the buyer asked for. The
+// original price fields stay in the shop currency.
+type ConvertedPrice struct {
+	Currency       string      `json:"currency"`
+	Rate           string      `json:"rate"`
+	Price          types.Money `json:"price"`
+	EffectivePrice types.Money `json:"effective_price"`
+}
+
+// NewConvertedPrice converts price and effective at rate into currency.
+func NewConvertedPrice(currency string, rate *big.Rat, price, effective types.Money) (*ConvertedPrice, error) {
+	var (
+		resp = &ConvertedPrice{Currency: currency, Rate: rate.FloatString(12)}
+		err  error
+	)
+
+	if resp.Price, err = price.Convert(currency, rate); err != nil {
+		return nil, err
+	}
+	if resp.EffectivePrice, err = effective.Convert(currency, rate); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetCurrency labels the prices, which are scanned before the shop currency
+// is known, with the shop currency.
+func (r *GetProductDetailResponse) SetCurrency(currency string) error {
+	r.Currency = currency
+
+	prices := []*types.Money{&r.Price, &r.EffectivePrice, &r.LowestPrice30Days}
+	if r.FlashSale != nil {
+		prices = append(prices, &r.FlashSale.Price)
+	}
+
+	return setCurrency(currency, prices...)
+}
+
+// SetCurrency labels the prices with the shop currency.
+func (i *ProductItem) SetCurrency(currency string) error {
+	i.Currency = currency
+
+	prices := []*types.Money{&i.Price, &i.EffectivePrice}
+	if i.FlashSale != nil {
+		prices = append(prices, &i.FlashSale.Price)
+	}
+
+	return setCurrency(currency, prices...)
+}
+
+// ClampSale keeps the effective price at most the regular price. A sale price
+// is only checked against the price when the sale is set, so a price lowered
+// later below it must not be raised by the sale, nor show its countdown.
+func (r *GetProductDetailResponse) ClampSale() {
+	clampSale(r.Price, &r.EffectivePrice, &r.SaleEndsAt)
+}
+
+// ClampSale keeps the effective price at most the regular price, see
+// GetProductDetailResponse.ClampSale.
+func (i *ProductItem) ClampSale() {
+	clampSale(i.Price, &i.EffectivePrice, &i.SaleEndsAt)
+}
+
+func clampSale(price types.Money, effective *types.Money, saleEndsAt **time.Time) {
+	if cmp, err := effective.Cmp(price); err != nil || cmp <= 0 {
+		return
+	}
+
+	*effective = price
+	*saleEndsAt = nil
+}
+
+func setCurrency(currency string, prices ...*types.Money) error {
+	for _, price := range prices {
+		relabeled, err := price.WithCurrency(currency)
+		if err != nil {
+			return err
+		}
+		*price = relabeled
+	}
+
+	return nil
+}
+
+// TaxClassItem is the tax class that applies to a product: the shop
+// override if any, else the category tax class.
+type TaxClassItem struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Rate string `json:"rate"`
+}
+
+// PriceBreakdown splits a price into the amount before tax, the tax and the
+// amount the buyer pays.
+type PriceBreakdown struct {
+	NetPrice   types.Money `json:"net_price"`
+	TaxAmount  types.Money `json:"tax_amount"`
+	GrossPrice types.Money `json:"gross_price"`
+}
+
 type CategoryItem struct{
 	Id string `json:"id" db:"id"`
 	Name string `json:"name" db:"name"`
 }
+
+type ExportProductsRequest struct {
+	UserId      string  `prop:"user_id" validate:"uuid"`
+	ShopId      string  `query:"shop_id" validate:"required,uuid"`
+	Format      string  `query:"format" validate:"required,oneof=csv ndjson"`
+	ProductName string  `query:"name"`
+	Brand       string  `query:"brand"`
+	CategoryId  string  `query:"category"`
+	MinPrice    types.Money `query:"min_price"`
+	MaxPrice    types.Money `query:"max_price"`
+	// Status limits the export to one status, every status when empty. Only
+	// the shop owner can export, so flagged and rejected products are fine.
+	Status string `query:"status" validate:"omitempty,oneof=active inactive flagged rejected"`
+	// Attributes is parsed from repeated attr[key]=value parameters by the
+	// handler, like GetProductsRequest.
+	Attributes []AttributeFilter `query:"-" validate:"max=10,dive"`
+	Tag        string            `query:"tag" validate:"omitempty,max=50"`
+	// Locales are the translations the name filter also matches, from
+	// Accept-Language.
+	Locales []string `query:"-"`
+}
+
+func (r *ExportProductsRequest) SetDefault() {
+	if r.Format == "" {
+		r.Format = "csv"
+	}
+}
+
+type ExportProductItem struct {
+	Id           string  `json:"id" db:"id"`
+	Name         string  `json:"name" db:"name"`
+	Brand        string  `json:"brand" db:"brand"`
+	Price        types.Money `json:"price" db:"price"`
+	Currency     string  `json:"currency" db:"currency"`
+	Stock        int     `json:"stock" db:"stock"`
+	Status       string  `json:"status" db:"status"`
+	CategoryId   string  `json:"category_id" db:"category_id"`
+	CategoryName string  `json:"category_name" db:"category_name"`
+	ShopId       string  `json:"shop_id" db:"shop_id"`
+	ShopName     string  `json:"shop_name" db:"shop_name"`
+	Description  string  `json:"description" db:"description"`
+	DescriptionFormat string `json:"description_format" db:"description_format"`
+	ImageUrl     string  `json:"image_url" db:"image_url"`
+}
+
+const (
+	ProductStatusActive   = "active"
+	ProductStatusInactive = "inactive"
+	// ProductStatusFlagged products wait for a moderation review and
+	// ProductStatusRejected ones were refused by it. Both are hidden and
+	// only a moderator changes them.
+	ProductStatusFlagged  = "flagged"
+	ProductStatusRejected = "rejected"
+
+	// ProductTypeBundle products are sold as a set of ProductTypeSingle
+	// products, see BundleItem.
+	ProductTypeSingle = "single"
+	ProductTypeBundle = "bundle"
+
+	BulkResultUpdated         = "updated"
+	BulkResultNotFound        = "not_found"
+	BulkResultForbidden       = "forbidden"
+	BulkResultValidationError = "validation_error"
+)
+
+type BulkUpdateProductsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Items []BulkUpdateProductItem `json:"items" validate:"required,min=1,max=500"`
+}
+
+// BulkUpdateProductItem only changes the fields that are present.
+type BulkUpdateProductItem struct {
+	Id     string   `json:"id" validate:"required,uuid"`
+	Price  *types.Money `json:"price" validate:"omitempty,gt=0"`
+	Stock  *int     `json:"stock" validate:"omitempty,min=0"`
+	Status *string  `json:"status" validate:"omitempty,oneof=active inactive"`
+}
+
+type BulkUpdateProductResult struct {
+	Id     string              `json:"id"`
+	Result string              `json:"result"`
+	Errors map[string][]string `json:"errors,omitempty"`
+
+	Before *ProductPricing `json:"-"`
+	After  *ProductPricing `json:"-"`
+}
+
+type ProductPricing struct {
+	Price  types.Money `json:"price" db:"price"`
+	Stock  int     `json:"stock" db:"stock"`
+	Status string  `json:"status" db:"status"`
+}
+
+type BulkUpdateProductsResponse struct {
+	Items   []BulkUpdateProductResult `json:"items"`
+	Updated int                       `json:"updated"`
+	Failed  int                       `json:"failed"`
+}
+
+type SetProductSaleRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id        string      `params:"id" validate:"uuid"`
+	SalePrice types.Money `json:"sale_price" validate:"required,gt=0"`
+	StartsAt  time.Time   `json:"starts_at" validate:"required"`
+	EndsAt    time.Time   `json:"ends_at" validate:"required,gtfield=StartsAt"`
+}
+
+type DeleteProductSaleRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+}
+
+type ProductSaleResponse struct {
+	Id        string       `json:"id" db:"id"`
+	SalePrice *types.Money `json:"sale_price" db:"sale_price"`
+	StartsAt  *time.Time   `json:"starts_at" db:"sale_starts_at"`
+	EndsAt    *time.Time   `json:"ends_at" db:"sale_ends_at"`
+}
+
+// ProductOwnership is what the service needs to authorize a change to a
+// product: the owner of the shop it belongs to and its regular price, in the
+// shop currency.
+type ProductOwnership struct {
+	Id       string      `db:"id"`
+	ShopId   string      `db:"shop_id"`
+	UserId   string      `db:"user_id"`
+	Type     string      `db:"type"`
+	Price    types.Money `db:"price"`
+	Currency string      `db:"currency"`
+	Status   string      `db:"status"`
+}
+
+type ShopOwnership struct {
+	Id       string `db:"id"`
+	UserId   string `db:"user_id"`
+	Currency string `db:"currency"`
+}
+
+type GetPriceHistoryRequest struct {
+	UserId string `prop:"user_id" validate:"omitempty,uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+
+	Page     int `query:"page" validate:"required"`
+	Paginate int `query:"paginate" validate:"required"`
+}
+
+func (r *GetPriceHistoryRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type PriceHistoryItem struct {
+	Price         types.Money  `json:"price" db:"price"`
+	PreviousPrice *types.Money `json:"previous_price" db:"previous_price"`
+	ChangedBy     *string      `json:"changed_by" db:"changed_by"`
+	ChangedAt     time.Time    `json:"changed_at" db:"changed_at"`
+}
+
+type GetPriceHistoryResponse struct {
+	Items             []PriceHistoryItem `json:"items"`
+	LowestPrice30Days types.Money        `json:"lowest_price_30d"`
+	Currency          string             `json:"currency"`
+	Meta              types.Meta         `json:"meta"`
+}
+
+// SetCurrency labels the prices with the shop currency.
+func (r *GetPriceHistoryResponse) SetCurrency(currency string) error {
+	r.Currency = currency
+
+	prices := []*types.Money{&r.LowestPrice30Days}
+	for i := range r.Items {
+		prices = append(prices, &r.Items[i].Price)
+		if r.Items[i].PreviousPrice != nil {
+			prices = append(prices, r.Items[i].PreviousPrice)
+		}
+	}
+
+	return setCurrency(currency, prices...)
+}
+
+// PriceTier is a wholesale quantity break. Quantities below the first tier
+// pay the regular price; MaxQuantity is nil for the last, open-ended tier.
+type PriceTier struct {
+	MinQuantity int         `json:"min_quantity" validate:"required,min=2" db:"min_quantity"`
+	MaxQuantity *int        `json:"max_quantity" validate:"omitempty,min=2" db:"max_quantity"`
+	Price       types.Money `json:"price" validate:"required,gt=0" db:"price"`
+}
+
+// ValidatePriceTiers expects tiers sorted by MinQuantity. Tiers must follow
+// each other without gaps or overlaps, only the last may be open-ended, and
+// every tier must be cheaper than the one before it and the regular price.
+// It returns the field errors, or nil when the tiers are valid.
+func ValidatePriceTiers(tiers []PriceTier, price types.Money) map[string][]string {
+	errs := make(map[string][]string)
+
+	for i, tier := range tiers {
+		field := fmt.Sprintf("tiers[%d]", i)
+
+		if tier.MaxQuantity != nil && *tier.MaxQuantity < tier.MinQuantity {
+			errs[field+".max_quantity"] = append(errs[field+".max_quantity"], "max quantity harus lebih dari atau sama dengan min quantity.")
+		}
+
+		if i == 0 {
+			if cmp, err := tier.Price.Cmp(price); err != nil || cmp >= 0 {
+				errs[field+".price"] = append(errs[field+".price"], "harga tier harus kurang dari harga produk.")
+			}
+			continue
+		}
+
+		prev := tiers[i-1]
+		switch {
+		case prev.MaxQuantity == nil:
+			prevField := fmt.Sprintf("tiers[%d].max_quantity", i-1)
+			errs[prevField] = append(errs[prevField], "hanya tier terakhir yang boleh tanpa max quantity.")
+		case tier.MinQuantity != *prev.MaxQuantity+1:
+			errs[field+".min_quantity"] = append(errs[field+".min_quantity"], fmt.Sprintf("min quantity harus %d agar tier tidak tumpang tindih atau berjarak.", *prev.MaxQuantity+1))
+		}
+
+		if cmp, err := tier.Price.Cmp(prev.Price); err != nil || cmp >= 0 {
+			errs[field+".price"] = append(errs[field+".price"], "harga tier harus kurang dari harga tier sebelumnya.")
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+type SetPriceTiersRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id    string      `params:"id" validate:"uuid"`
+	Tiers []PriceTier `json:"tiers" validate:"max=20,dive"`
+}
+
+type PriceTiersResponse struct {
+	Id    string      `json:"id"`
+	Tiers []PriceTier `json:"tiers"`
+}
+
+type GetPriceQuoteRequest struct {
+	UserId string `prop:"user_id" validate:"omitempty,uuid"`
+
+	Id       string `params:"id" validate:"uuid"`
+	Quantity int    `query:"quantity" validate:"required,min=1"`
+}
+
+type GetPriceQuoteResponse struct {
+	Id         string      `json:"id"`
+	Quantity   int         `json:"quantity"`
+	UnitPrice  types.Money `json:"unit_price"`
+	TotalPrice types.Money `json:"total_price"`
+	Tier       *PriceTier  `json:"tier"`
+	// PriceBreakdown is computed on the total so per-unit rounding does not
+	// add up.
+	PriceBreakdown *PriceBreakdown `json:"price_breakdown"`
+}
+
+// DiscountPercent returns how much cheaper effective is than price, rounded
+// down to a whole percent.
+func DiscountPercent(price, effective types.Money) int {
+	if price.Amount <= 0 || effective.Amount >= price.Amount {
+		return 0
+	}
+
+	return int((price.Amount - effective.Amount) * 100 / price.Amount)
+}
+
+const (
+	SpecTypeText    = "text"
+	SpecTypeNumber  = "number"
+	SpecTypeBoolean = "boolean"
+)
+
+// Specification is a typed key-value attribute of a product, e.g. key "ram",
+// value 8, unit "GB". Value is a string, number or boolean according to Type.
+type Specification struct {
+	Key   string `json:"key" validate:"required,max=100"`
+	Label string `json:"label" validate:"omitempty,max=100"`
+	Type  string `json:"type" validate:"required,oneof=text number boolean"`
+	Value any    `json:"value"`
+	Unit  string `json:"unit" validate:"omitempty,max=20"`
+}
+
+type SetSpecificationsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id             string          `params:"id" validate:"uuid"`
+	Specifications []Specification `json:"specifications" validate:"max=50,dive"`
+}
+
+type SpecificationsResponse struct {
+	Id             string          `json:"id"`
+	Specifications []Specification `json:"specifications"`
+}
+
+type AttributeFilter struct {
+	Key    string   `json:"key" validate:"required,max=100"`
+	Values []string `json:"values" validate:"min=1,max=20,dive,required,max=255"`
+}
+
+var (
+	specSpaces = regexp.MustCompile(`\s+`)
+	specNumber = regexp.MustCompile(`^([+-]?[0-9]+(?:\.[0-9]+)?)\s*(.*)$`)
+)
+
+// NormalizeSpecKey turns a label like "Screen Size" into the key
+// "screen_size".
+func NormalizeSpecKey(key string) string {
+	return specSpaces.ReplaceAllString(strings.ToLower(strings.TrimSpace(key)), "_")
+}
+
+// NormalizeSpecValue is the form specification values are stored and
+// filtered in: lower case, single spaces, and a leading number written
+// canonically and joined to its unit, so "8 GB", "8GB" and "8.0gb" all
+// become "8gb".
+func NormalizeSpecValue(value string) string {
+	value = specSpaces.ReplaceAllString(strings.ToLower(strings.TrimSpace(value)), " ")
+
+	m := specNumber.FindStringSubmatch(value)
+	if m == nil {
+		return value
+	}
+
+	n, ok := new(big.Rat).SetString(m[1])
+	if !ok {
+		return value
+	}
+
+	return FormatSpecNumber(n) + m[2]
+}
+
+// FormatSpecNumber writes n without trailing zeros.
+func FormatSpecNumber(n *big.Rat) string {
+	s := n.FloatString(6)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// TagItem is a tag resolved for assignment: merged tags are replaced by the
+// tag they were merged into.
+type TagItem struct {
+	Id     string `db:"id"`
+	Name   string `db:"name"`
+	Banned bool   `db:"banned"`
+}
+
+var tagInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+// NormalizeTag turns "Ramadan Sale!" into "ramadan-sale".
+func NormalizeTag(tag string) string {
+	return strings.Trim(tagInvalid.ReplaceAllString(strings.ToLower(tag), "-"), "-")
+}
+
+// DescriptionExcerptLength is the longest plain text excerpt of a
+// description shown on listing cards.
+const DescriptionExcerptLength = 200
+
+// Translation is the text of a product in another language than it was
+// written in.
+type Translation struct {
+	Locale      string    `json:"locale" db:"locale"`
+	Name        string    `json:"name" db:"name"`
+	Description       string    `json:"description" db:"description"`
+	DescriptionFormat string    `json:"description_format" db:"description_format"`
+	DescriptionHtml   string    `json:"description_html" db:"description_html"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type GetTranslationsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+}
+
+type GetTranslationsResponse struct {
+	Id    string        `json:"id"`
+	Items []Translation `json:"items"`
+}
+
+type SetTranslationRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id          string `params:"id" validate:"uuid"`
+	Locale      string `params:"locale" validate:"required,locale"`
+	Name        string `json:"name" validate:"required,min=3,max=100"`
+	Description string `json:"description" validate:"max=20000"`
+	DescriptionFormat  string `json:"description_format" validate:"omitempty,oneof=plain markdown html"`
+	DescriptionHtml    string `json:"-"`
+	DescriptionExcerpt string `json:"-"`
+}
+
+type DeleteTranslationRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id     string `params:"id" validate:"uuid"`
+	Locale string `params:"locale" validate:"required,locale"`
+}
+
+type SetCategoryTranslationRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	CategoryId string `params:"id" validate:"uuid"`
+	Locale     string `params:"locale" validate:"required,locale"`
+	Name       string `json:"name" validate:"required,max=100"`
+}
+
+type DeleteCategoryTranslationRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	CategoryId string `params:"id" validate:"uuid"`
+	Locale     string `params:"locale" validate:"required,locale"`
+}
+
+// MaxSimilarProducts is how many similar products of a product are ranked
+// and cached.
+const MaxSimilarProducts = 50
+
+// SimilarProductsCacheTTL is how long the ranking of the similar products of
+// a product is reused before it is computed again.
+const SimilarProductsCacheTTL = 6 * time.Hour
+
+type GetSimilarProductsRequest struct {
+	UserId string `prop:"user_id" validate:"omitempty,uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+
+	// ExcludeSameShop leaves out the other products of the shop.
+	ExcludeSameShop bool `query:"exclude_same_shop"`
+	Limit           int  `query:"limit" validate:"min=1,max=50"`
+}
+
+func (r *GetSimilarProductsRequest) SetDefault() {
+	if r.Limit < 1 {
+		r.Limit = 12
+	}
+}
+
+// SimilarScore is how similar a product is to another, from 0 to 1.
+type SimilarScore struct {
+	Id    string  `json:"id" db:"id"`
+	Score float64 `json:"score" db:"score"`
+}
+
+type SimilarProductItem struct {
+	ProductItem
+	Score float64 `json:"score"`
+}
+
+type GetSimilarProductsResponse struct {
+	Items []SimilarProductItem `json:"items"`
+}
+
+// DuplicatePolicy is what happens to a product that is a near duplicate of
+// another product of its shop.
+type DuplicatePolicy string
+
+const (
+	// DuplicatePolicyReject refuses to save it.
+	DuplicatePolicyReject DuplicatePolicy = "reject"
+	// DuplicatePolicyFlag saves it, pointing to the product it duplicates
+	// for the admin duplicates report.
+	DuplicatePolicyFlag DuplicatePolicy = "flag"
+	DuplicatePolicyOff  DuplicatePolicy = "off"
+)
+
+// Two products of a shop with the same brand and category are near
+// duplicates when their names are at least DuplicateNameSimilarity alike, by
+// trigrams of the lower case words, and their prices at most
+// DuplicatePriceTolerance of the lower one apart.
+const (
+	DuplicateNameSimilarity = 0.8
+	DuplicatePriceTolerance = 0.1
+)
+
+// DuplicateCheck describes a product being saved; ExcludeId is the product
+// itself on update.
+type DuplicateCheck struct {
+	ShopId     string
+	ExcludeId  string
+	Name       string
+	Brand      string
+	CategoryId string
+	Price      types.Money
+}
+
+type DuplicateMatch struct {
+	Id    string  `json:"id" db:"id"`
+	Name  string  `json:"name" db:"name"`
+	Score float64 `json:"score" db:"score"`
+}
+
+// DuplicatePair is a product and a later product of the same shop that is
+// its near duplicate.
+type DuplicatePair struct {
+	ProductId   string `db:"product_id"`
+	DuplicateId string `db:"duplicate_id"`
+}
+
+type GetDuplicateClustersRequest struct {
+	ShopId string `query:"shop_id" validate:"omitempty,uuid"`
+
+	Page     int `query:"page" validate:"required"`
+	Paginate int `query:"paginate" validate:"required"`
+}
+
+func (r *GetDuplicateClustersRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+// DuplicateCluster is a group of products of a shop that are near
+// duplicates of each other, directly or through another product of the
+// group, oldest first.
+type DuplicateCluster struct {
+	ShopId string          `json:"shop_id"`
+	Items  []DuplicateItem `json:"items"`
+}
+
+type DuplicateItem struct {
+	Id          string      `json:"id" db:"id"`
+	ShopId      string      `json:"-" db:"shop_id"`
+	Name        string      `json:"name" db:"name"`
+	Brand       string      `json:"brand" db:"brand"`
+	CategoryId  string      `json:"category_id" db:"category_id"`
+	Price       types.Money `json:"price" db:"price"`
+	Currency    string      `json:"currency" db:"currency"`
+	Status      string      `json:"status" db:"status"`
+	DuplicateOf *string     `json:"duplicate_of" db:"duplicate_of"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+}
+
+type GetDuplicateClustersResponse struct {
+	Items []DuplicateCluster `json:"items"`
+	Meta  types.Meta         `json:"meta"`
+}
+
+// ModerationReasonResubmitted queues a rejected product the seller updated
+// without any banned keyword left.
+const ModerationReasonResubmitted = "Diubah setelah ditolak"
+
+// ModerationContent is what a seller writes about a product, screened
+// against the banned keywords of its category.
+type ModerationContent struct {
+	CategoryId  string
+	Name        string
+	Description string
+}
+
+// CurrencyMessage tells a seller that a price must be in the shop currency.
+// Prices are stored as bare amounts, so a price in another currency would be
+// read back as the shop currency.
+func CurrencyMessage(currency string) string {
+	return "mata uang harus " + currency + ", sesuai mata uang toko."
+}
+
+// BundleStockMessage tells a seller that a bundle stock cannot be set.
+const BundleStockMessage = "stok bundel dihitung dari stok produk komponennya."
+
+// BundleItem is a component of a bundle: Quantity units of a single product
+// of the same shop go into each bundle sold.
+type BundleItem struct {
+	ProductId string `json:"product_id" validate:"required,uuid"`
+	Quantity  int    `json:"quantity" validate:"required,min=1,max=100"`
+}
+
+// BundleComponent is a BundleItem with the component as it is now. Stock
+// is the stock of the component, not the bundles it makes.
+type BundleComponent struct {
+	ProductId string `json:"product_id" db:"product_id"`
+	Name      string `json:"name" db:"name"`
+	Slug      string `json:"slug" db:"slug"`
+	Quantity  int    `json:"quantity" db:"quantity"`
+	Stock     int    `json:"stock" db:"stock"`
+	Status    string `json:"status" db:"status"`
+}
+
+// ErrOutOfStock is returned when the stock of a product, or of a component
+// of a bundle, cannot cover the quantity taken.
+var ErrOutOfStock = errors.New("product out of stock")
+
+// BundleStock is how many complete bundles the components make. A component
+// that is not active makes none.
+func BundleStock(items []BundleComponent) int {
+	if len(items) == 0 {
+		return 0
+	}
+
+	stock := math.MaxInt
+	for _, item := range items {
+		if item.Status != ProductStatusActive {
+			return 0
+		}
+		stock = min(stock, item.Stock/item.Quantity)
+	}
+
+	return stock
+}
+
+// BundleCandidate is what a product must be checked for before it goes
+// into a bundle.
+type BundleCandidate struct {
+	Id     string `db:"id"`
+	ShopId string `db:"shop_id"`
+	Type   string `db:"type"`
+}
+
+type SetBundleItemsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id    string       `params:"id" validate:"uuid"`
+	Items []BundleItem `json:"items" validate:"required,min=2,max=10,dive"`
+}
+
+type BundleItemsResponse struct {
+	Id    string            `json:"id"`
+	Stock int               `json:"stock"`
+	Items []BundleComponent `json:"items"`
+}
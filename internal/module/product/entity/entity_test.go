@@ -0,0 +1,190 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscountPercent(t *testing.T) {
+	idr := func(amount int64) types.Money { return types.NewMoney(amount*100, "IDR") }
+
+	assert.Equal(t, 25, DiscountPercent(idr(100000), idr(75000)))
+	assert.Equal(t, 33, DiscountPercent(idr(30000), idr(20000)))
+	assert.Equal(t, 0, DiscountPercent(idr(100000), idr(100000)))
+	assert.Equal(t, 0, DiscountPercent(types.Money{}, idr(100000)))
+
+	// the price was lowered to 60000 after a sale of 80000 was set: the
+	// effective price is the price, never the higher sale price
+	assert.Equal(t, 0, DiscountPercent(idr(60000), idr(60000)))
+	assert.Equal(t, 0, DiscountPercent(idr(60000), idr(80000)))
+}
+
+func TestClampSale(t *testing.T) {
+	var (
+		idr     = func(amount int64) types.Money { return types.NewMoney(amount*100, "IDR") }
+		endsAt  = time.Now().Add(time.Hour)
+		running = func(price, effective types.Money) *ProductItem {
+			return &ProductItem{Price: price, EffectivePrice: effective, SaleEndsAt: &endsAt}
+		}
+	)
+
+	// a sale of 80000 set while the price was 100000 still applies
+	item := running(idr(100000), idr(80000))
+	item.ClampSale()
+	assert.Equal(t, idr(80000), item.EffectivePrice)
+	assert.Equal(t, &endsAt, item.SaleEndsAt)
+
+	// the price was lowered to 60000 after the sale was set: the stale sale
+	// price must not raise it, and there is no sale to count down
+	item = running(idr(60000), idr(80000))
+	item.ClampSale()
+	assert.Equal(t, idr(60000), item.EffectivePrice)
+	assert.Nil(t, item.SaleEndsAt)
+	assert.Equal(t, 0, DiscountPercent(item.Price, item.EffectivePrice))
+
+	detail := &GetProductDetailResponse{Price: idr(60000), EffectivePrice: idr(80000), SaleEndsAt: &endsAt}
+	detail.ClampSale()
+	assert.Equal(t, idr(60000), detail.EffectivePrice)
+	assert.Nil(t, detail.SaleEndsAt)
+
+	// a flash sale without a sale window is left alone
+	item = &ProductItem{Price: idr(100000), EffectivePrice: idr(50000)}
+	item.ClampSale()
+	assert.Equal(t, idr(50000), item.EffectivePrice)
+}
+
+func TestValidatePriceTiers(t *testing.T) {
+	var (
+		idr   = func(amount int64) types.Money { return types.NewMoney(amount*100, "IDR") }
+		upTo  = func(n int) *int { return &n }
+		price = idr(10000)
+	)
+
+	tests := []struct {
+		name  string
+		tiers []PriceTier
+		want  map[string][]string
+	}{
+		{
+			name: "no tiers",
+		},
+		{
+			name: "descending with an open-ended last tier",
+			tiers: []PriceTier{
+				{MinQuantity: 2, MaxQuantity: upTo(9), Price: idr(9500)},
+				{MinQuantity: 10, MaxQuantity: upTo(49), Price: idr(9000)},
+				{MinQuantity: 50, Price: idr(8500)},
+			},
+		},
+		{
+			name: "first tier not cheaper than the price",
+			tiers: []PriceTier{
+				{MinQuantity: 2, Price: idr(10000)},
+			},
+			want: map[string][]string{"tiers[0].price": {"harga tier harus kurang dari harga produk."}},
+		},
+		{
+			name: "max below min",
+			tiers: []PriceTier{
+				{MinQuantity: 10, MaxQuantity: upTo(5), Price: idr(9000)},
+			},
+			want: map[string][]string{"tiers[0].max_quantity": {"max quantity harus lebih dari atau sama dengan min quantity."}},
+		},
+		{
+			name: "overlap",
+			tiers: []PriceTier{
+				{MinQuantity: 2, MaxQuantity: upTo(10), Price: idr(9500)},
+				{MinQuantity: 10, Price: idr(9000)},
+			},
+			want: map[string][]string{"tiers[1].min_quantity": {"min quantity harus 11 agar tier tidak tumpang tindih atau berjarak."}},
+		},
+		{
+			name: "gap",
+			tiers: []PriceTier{
+				{MinQuantity: 2, MaxQuantity: upTo(9), Price: idr(9500)},
+				{MinQuantity: 12, Price: idr(9000)},
+			},
+			want: map[string][]string{"tiers[1].min_quantity": {"min quantity harus 10 agar tier tidak tumpang tindih atau berjarak."}},
+		},
+		{
+			name: "not descending",
+			tiers: []PriceTier{
+				{MinQuantity: 2, MaxQuantity: upTo(9), Price: idr(9000)},
+				{MinQuantity: 10, Price: idr(9000)},
+			},
+			want: map[string][]string{"tiers[1].price": {"harga tier harus kurang dari harga tier sebelumnya."}},
+		},
+		{
+			name: "open-ended tier before the last",
+			tiers: []PriceTier{
+				{MinQuantity: 2, Price: idr(9500)},
+				{MinQuantity: 10, Price: idr(9000)},
+			},
+			want: map[string][]string{"tiers[0].max_quantity": {"hanya tier terakhir yang boleh tanpa max quantity."}},
+		},
+		{
+			name: "price in another currency",
+			tiers: []PriceTier{
+				{MinQuantity: 2, Price: types.NewMoney(500, "USD")},
+			},
+			want: map[string][]string{"tiers[0].price": {"harga tier harus kurang dari harga produk."}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidatePriceTiers(tt.tiers, price))
+		})
+	}
+}
+
+func TestBundleStock(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []BundleComponent
+		want  int
+	}{
+		{name: "no components", want: 0},
+		{
+			name: "fewest complete sets",
+			items: []BundleComponent{
+				{Quantity: 2, Stock: 9, Status: ProductStatusActive},
+				{Quantity: 1, Stock: 6, Status: ProductStatusActive},
+			},
+			want: 4,
+		},
+		{
+			name: "component out of stock",
+			items: []BundleComponent{
+				{Quantity: 2, Stock: 1, Status: ProductStatusActive},
+				{Quantity: 1, Stock: 6, Status: ProductStatusActive},
+			},
+			want: 0,
+		},
+		{
+			name: "component not active",
+			items: []BundleComponent{
+				{Quantity: 1, Stock: 10, Status: ProductStatusActive},
+				{Quantity: 1, Stock: 10, Status: ProductStatusFlagged},
+			},
+			want: 0,
+		},
+		{
+			name: "component inactive",
+			items: []BundleComponent{
+				{Quantity: 1, Stock: 10, Status: ProductStatusInactive},
+				{Quantity: 1, Stock: 10, Status: ProductStatusActive},
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BundleStock(tt.items))
+		})
+	}
+}
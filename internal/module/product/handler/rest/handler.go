@@ -1,14 +1,31 @@
 package handler
 
 import (
+	"bufio"
 	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
 	"codebase-app/internal/middleware"
+	auditRepository "codebase-app/internal/module/audit/repository"
+	auditService "codebase-app/internal/module/audit/service"
+	exchangeRateRepository "codebase-app/internal/module/exchange_rate/repository"
+	exchangeRateService "codebase-app/internal/module/exchange_rate/service"
+	moderationRepository "codebase-app/internal/module/moderation/repository"
+	moderationService "codebase-app/internal/module/moderation/service"
+	notificationRepository "codebase-app/internal/module/notification/repository"
+	notificationService "codebase-app/internal/module/notification/service"
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
 	"codebase-app/internal/module/product/repository"
 	"codebase-app/internal/module/product/service"
 	"codebase-app/pkg/errmsg"
 	"codebase-app/pkg/response"
+	"codebase-app/pkg/types"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
@@ -21,7 +38,11 @@ func NewProductHandler() *productHandler {
 	var(
 		handler = new(productHandler)
 		repo = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
-		service = service.NewProductService(repo)
+		audit = auditService.NewAuditService(auditRepository.NewAuditRepository(adapter.Adapters.ShopeefunPostgres))
+		rates = exchangeRateService.NewExchangeRateService(exchangeRateRepository.NewExchangeRateRepository(adapter.Adapters.ShopeefunPostgres))
+		notification = notificationService.NewNotificationService(notificationRepository.NewNotificationRepository(adapter.Adapters.ShopeefunPostgres))
+		moderator = moderationService.NewModerationService(moderationRepository.NewModerationRepository(adapter.Adapters.ShopeefunPostgres), notification)
+		service = service.NewProductService(repo, audit, adapter.Adapters.Validator, rates, service.NewPricingService(), service.NewDuplicateDetector(repo, entity.DuplicatePolicy(config.Envs.Product.DuplicatePolicy)), moderator)
 	)
 	handler.service = service
 
@@ -29,10 +50,27 @@ func NewProductHandler() *productHandler {
 }
 
 func (h *productHandler) Register(router fiber.Router) {
-	router.Post("/product", middleware.UserIdHeader, h.CreateProduct)
-	router.Get("/product/:id", h.GetDetailProduct)
+	router.Post("/product", middleware.UserIdHeader, middleware.IdempotencyKey, h.CreateProduct)
+	router.Get("/product/export", middleware.UserIdHeader, h.ExportProducts)
+	router.Patch("/product/bulk", middleware.UserIdHeader, h.BulkUpdateProducts)
+	router.Get("/product/duplicates", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.GetDuplicateClusters)
+	router.Get("/product/:id", middleware.OptionalUserIdHeader, h.GetDetailProduct)
+	router.Get("/shops/:id/products/:slug", middleware.OptionalUserIdHeader, h.GetProductBySlug)
 	router.Patch("/product/:id", middleware.UserIdHeader, h.UpdateProduct)
 	router.Delete("/product/:id", middleware.UserIdHeader, h.DeleteProduct)
+	router.Put("/product/:id/sale", middleware.UserIdHeader, h.SetProductSale)
+	router.Delete("/product/:id/sale", middleware.UserIdHeader, h.DeleteProductSale)
+	router.Get("/product/:id/price-history", middleware.OptionalUserIdHeader, h.GetPriceHistory)
+	router.Get("/product/:id/similar", middleware.OptionalUserIdHeader, h.GetSimilarProducts)
+	router.Put("/product/:id/price-tiers", middleware.UserIdHeader, h.SetPriceTiers)
+	router.Get("/product/:id/price-quote", middleware.OptionalUserIdHeader, h.GetPriceQuote)
+	router.Put("/product/:id/specifications", middleware.UserIdHeader, h.SetSpecifications)
+	router.Put("/product/:id/bundle", middleware.UserIdHeader, h.SetBundleItems)
+	router.Get("/product/:id/translations", middleware.UserIdHeader, h.GetTranslations)
+	router.Put("/product/:id/translations/:locale", middleware.UserIdHeader, h.SetTranslation)
+	router.Delete("/product/:id/translations/:locale", middleware.UserIdHeader, h.DeleteTranslation)
+	router.Put("/categories/:id/translations/:locale", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.SetCategoryTranslation)
+	router.Delete("/categories/:id/translations/:locale", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.DeleteCategoryTranslation)
 	router.Get("/product", middleware.UserIdHeader, h.GetProducts)
 }
 
@@ -60,7 +98,8 @@ func (h *productHandler) CreateProduct(c *fiber.Ctx) error {
 	resp, err := h.service.CreateProduct(ctx, req)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("handler::CreateProduct - Failed to create product")
-		return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, "Produk berhasil dibuat"))
@@ -73,7 +112,10 @@ func (h *productHandler) GetDetailProduct(c *fiber.Ctx) error {
 		v = adapter.Adapters.Validator
 	)
 
+	req.UserId, _ = c.Locals("user_id").(string)
 	req.Id = c.Params("id")
+	req.Currency = c.Query("currency")
+	req.Locales = preferredLocales(c)
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::GetProductDetail - Validate request body")
@@ -84,9 +126,49 @@ func (h *productHandler) GetDetailProduct(c *fiber.Ctx) error {
 	resp, err := h.service.GetDetailProduct(ctx, req)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("handler::GetDetailProduct - Failed to get product detail")
-		return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+// GetProductBySlug answers a previous slug of a renamed product with a
+// permanent redirect to its current one.
+func (h *productHandler) GetProductBySlug(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetProductBySlugRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	req.UserId, _ = c.Locals("user_id").(string)
+	req.ShopId = c.Params("id")
+	req.Slug = c.Params("slug")
+	req.Currency = c.Query("currency")
+	req.Locales = preferredLocales(c)
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetProductBySlug - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	resp, err := h.service.GetProductBySlug(ctx, req)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("handler::GetProductBySlug - Failed to get product")
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if resp.Slug != req.Slug {
+		location := strings.TrimSuffix(c.Path(), req.Slug) + url.PathEscape(resp.Slug)
+		if query := c.Context().QueryArgs().String(); query != "" {
+			location += "?" + query
+		}
+		return c.Redirect(location, fiber.StatusMovedPermanently)
+	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
@@ -116,7 +198,8 @@ func (h* productHandler) UpdateProduct(c *fiber.Ctx) error {
 	resp, err := h.service.UpdateProduct(ctx, req)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("handler::UpdateProduct - Failed to update product")
-		return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Produk berhasil diupdate"))
@@ -162,6 +245,8 @@ func (h* productHandler) GetProducts(c* fiber.Ctx) error{
 	}
 
 	req.UserId = l.UserId
+	req.Attributes = attributeFilters(c)
+	req.Locales = preferredLocales(c)
 	req.SetDefault()
 
 	if err := v.Validate(req); err != nil {
@@ -173,8 +258,584 @@ func (h* productHandler) GetProducts(c* fiber.Ctx) error{
 	resp, err := h.service.GetProducts(ctx, req)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("handler::GetProducts - Failed to get products")
-		return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
-}
\ No newline at end of file
+}
+
+// preferredLocales reads the translations to show from Accept-Language. The
+// response varies on the header, so caches must key on it.
+func preferredLocales(c *fiber.Ctx) []string {
+	c.Vary(fiber.HeaderAcceptLanguage)
+
+	return types.PreferredLocales(c.Get(fiber.HeaderAcceptLanguage), config.Envs.App.DefaultLocale)
+}
+
+// attributeFilters collects the repeated attr[key]=value query parameters in
+// the order their keys first appear.
+func attributeFilters(c *fiber.Ctx) []entity.AttributeFilter {
+	var (
+		filters []entity.AttributeFilter
+		index   = make(map[string]int)
+	)
+
+	c.Context().QueryArgs().VisitAll(func(k, v []byte) {
+		key := string(k)
+		if !strings.HasPrefix(key, "attr[") || !strings.HasSuffix(key, "]") {
+			return
+		}
+		key = key[len("attr[") : len(key)-1]
+
+		i, ok := index[key]
+		if !ok {
+			i = len(filters)
+			index[key] = i
+			filters = append(filters, entity.AttributeFilter{Key: key})
+		}
+		filters[i].Values = append(filters[i].Values, string(v))
+	})
+
+	return filters
+}
+
+// ExportProducts streams a shop's catalog as CSV or NDJSON. Rows are written
+// to the client as they are read from the database; once streaming has
+// started errors can only be logged.
+func (h *productHandler) ExportProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.ExportProductsRequest)
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::ExportProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Attributes = attributeFilters(c)
+	req.Locales = preferredLocales(c)
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ExportProducts - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.AuthorizeExport(c.Context(), req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::ExportProducts - Failed to authorize export")
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	c.Attachment("products-" + req.ShopId + "." + req.Format)
+	if req.Format == "ndjson" {
+		c.Set(fiber.HeaderContentType, "application/x-ndjson")
+	}
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		var (
+			// the fiber context must not be used once the handler returned
+			ctx   = context.Background()
+			count = 0
+			write func(item *entity.ExportProductItem) error
+		)
+
+		switch req.Format {
+		case "ndjson":
+			enc := json.NewEncoder(w)
+			write = func(item *entity.ExportProductItem) error {
+				return enc.Encode(item)
+			}
+		default:
+			cw := csv.NewWriter(w)
+			_ = cw.Write([]string{"id", "name", "brand", "price", "currency", "stock", "status", "category_id", "category_name", "shop_id", "shop_name", "description", "description_format", "image_url"})
+			write = func(item *entity.ExportProductItem) error {
+				err := cw.Write([]string{
+					item.Id,
+					item.Name,
+					item.Brand,
+					item.Price.String(),
+					item.Currency,
+					strconv.Itoa(item.Stock),
+					item.Status,
+					item.CategoryId,
+					item.CategoryName,
+					item.ShopId,
+					item.ShopName,
+					item.Description,
+					item.DescriptionFormat,
+					item.ImageUrl,
+				})
+				cw.Flush()
+				return err
+			}
+		}
+
+		err := h.service.ExportProducts(ctx, req, func(item *entity.ExportProductItem) error {
+			if err := write(item); err != nil {
+				return err
+			}
+
+			count++
+			if count%100 == 0 {
+				return w.Flush()
+			}
+			return nil
+		})
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Int("rows", count).Msg("handler::ExportProducts - Failed to stream products")
+		}
+
+		if err := w.Flush(); err != nil {
+			log.Warn().Err(err).Any("payload", req).Msg("handler::ExportProducts - Failed to flush response")
+		}
+	})
+
+	return nil
+}
+
+func (h *productHandler) BulkUpdateProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.BulkUpdateProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::BulkUpdateProducts - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::BulkUpdateProducts - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.BulkUpdateProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) SetProductSale(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetProductSaleRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetProductSale - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetProductSale - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.SetProductSale(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Harga promo berhasil disimpan"))
+}
+
+func (h *productHandler) DeleteProductSale(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteProductSaleRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteProductSale - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.DeleteProductSale(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Harga promo berhasil dihapus"))
+}
+
+func (h *productHandler) GetPriceHistory(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetPriceHistoryRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetPriceHistory - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId, _ = c.Locals("user_id").(string)
+	req.Id = c.Params("id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetPriceHistory - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetPriceHistory(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetSimilarProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetSimilarProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetSimilarProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId, _ = c.Locals("user_id").(string)
+	req.Id = c.Params("id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetSimilarProducts - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetSimilarProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetDuplicateClusters(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetDuplicateClustersRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetDuplicateClusters - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetDuplicateClusters - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetDuplicateClusters(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) SetPriceTiers(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetPriceTiersRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetPriceTiers - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetPriceTiers - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.SetPriceTiers(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Harga grosir berhasil disimpan"))
+}
+
+func (h *productHandler) GetPriceQuote(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetPriceQuoteRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetPriceQuote - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId, _ = c.Locals("user_id").(string)
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetPriceQuote - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetPriceQuote(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) SetSpecifications(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetSpecificationsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetSpecifications - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetSpecifications - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.SetSpecifications(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Spesifikasi produk berhasil disimpan"))
+}
+
+func (h *productHandler) SetBundleItems(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetBundleItemsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetBundleItems - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetBundleItems - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.SetBundleItems(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Komponen bundel berhasil disimpan"))
+}
+
+func (h *productHandler) GetTranslations(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetTranslationsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetTranslations - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetTranslations(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) SetTranslation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetTranslationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetTranslation - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+	req.Locale = c.Params("locale")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetTranslation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.SetTranslation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Terjemahan produk berhasil disimpan"))
+}
+
+func (h *productHandler) DeleteTranslation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteTranslationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+	req.Locale = c.Params("locale")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteTranslation - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.DeleteTranslation(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Terjemahan produk berhasil dihapus"))
+}
+
+func (h *productHandler) SetCategoryTranslation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetCategoryTranslationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetCategoryTranslation - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.CategoryId = c.Params("id")
+	req.Locale = c.Params("locale")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetCategoryTranslation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.SetCategoryTranslation(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Terjemahan kategori berhasil disimpan"))
+}
+
+func (h *productHandler) DeleteCategoryTranslation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteCategoryTranslationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.CategoryId = c.Params("id")
+	req.Locale = c.Params("locale")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteCategoryTranslation - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.DeleteCategoryTranslation(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Terjemahan kategori berhasil dihapus"))
+}
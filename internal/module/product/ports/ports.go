@@ -2,21 +2,102 @@ package ports
 
 import (
 	"codebase-app/internal/module/product/entity"
+	"codebase-app/pkg/types"
 	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
 )
 
 type ProductRepository interface {
 	CreateProduct(ctx context.Context, shop *entity.CreateProductRequest) (*entity.CreateProductResponse, error)
 	GetDetailProduct(ctx context.Context, shop *entity.GetProductDetailRequest) (*entity.GetProductDetailResponse, error)
+	GetProductIdBySlug(ctx context.Context, shopId, slug string) (string, error)
 	UpdateProduct(ctx context.Context, shop *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error)
 	DeleteProduct(ctx context.Context, shop *entity.DeleteProductRequest) (*entity.DeleteProductResponse, error)
 	GetProducts(ctx context.Context, shop *entity.GetProductsRequest) (*entity.GetProductsResponse, error)
+	GetProductsByIds(ctx context.Context, ids []string) ([]entity.ProductItem, error)
+	GetSimilarProducts(ctx context.Context, id string, excludeSameShop bool, limit int) ([]entity.SimilarScore, error)
+	GetSimilarProductsCache(ctx context.Context, id string, excludeSameShop bool) ([]entity.SimilarScore, error)
+	SetSimilarProductsCache(ctx context.Context, id string, excludeSameShop bool, items []entity.SimilarScore, ttl time.Duration) error
+	DeleteSimilarProductsCache(ctx context.Context, id string) error
+	FindDuplicates(ctx context.Context, req *entity.DuplicateCheck) ([]entity.DuplicateMatch, error)
+	SetDuplicateFlag(ctx context.Context, id string, match *entity.DuplicateMatch) error
+	GetDuplicatePairs(ctx context.Context, shopId string) ([]entity.DuplicatePair, error)
+	GetDuplicateItems(ctx context.Context, ids []string) ([]entity.DuplicateItem, error)
+	ExportProducts(ctx context.Context, req *entity.ExportProductsRequest, fn func(item *entity.ExportProductItem) error) error
+	BulkUpdateProducts(ctx context.Context, req *entity.BulkUpdateProductsRequest) ([]entity.BulkUpdateProductResult, error)
+	GetShopOwnership(ctx context.Context, shopId string) (*entity.ShopOwnership, error)
+	GetProductOwnership(ctx context.Context, id string) (*entity.ProductOwnership, error)
+	TakeStock(ctx context.Context, tx *sqlx.Tx, productId string, quantity int) error
+	GetProductSale(ctx context.Context, id string) (*entity.ProductSaleResponse, error)
+	SetProductSale(ctx context.Context, req *entity.SetProductSaleRequest) (*entity.ProductSaleResponse, error)
+	DeleteProductSale(ctx context.Context, req *entity.DeleteProductSaleRequest) error
+	GetPriceHistory(ctx context.Context, req *entity.GetPriceHistoryRequest) (*entity.GetPriceHistoryResponse, error)
+	GetPriceTiers(ctx context.Context, id string) ([]entity.PriceTier, error)
+	SetPriceTiers(ctx context.Context, req *entity.SetPriceTiersRequest) error
+	GetSpecifications(ctx context.Context, id string) ([]entity.Specification, error)
+	SetSpecifications(ctx context.Context, req *entity.SetSpecificationsRequest) error
+	GetBundleComponents(ctx context.Context, id string) ([]entity.BundleComponent, error)
+	GetBundleCandidates(ctx context.Context, ids []string) ([]entity.BundleCandidate, error)
+	SetBundleItems(ctx context.Context, req *entity.SetBundleItemsRequest) error
+	ResolveTags(ctx context.Context, names []string) ([]entity.TagItem, error)
+	SetProductTags(ctx context.Context, id string, tagIds []string) error
+	GetTranslations(ctx context.Context, id string) ([]entity.Translation, error)
+	SetTranslation(ctx context.Context, req *entity.SetTranslationRequest) (*entity.Translation, error)
+	DeleteTranslation(ctx context.Context, req *entity.DeleteTranslationRequest) error
+	SetCategoryTranslation(ctx context.Context, req *entity.SetCategoryTranslationRequest) error
+	DeleteCategoryTranslation(ctx context.Context, req *entity.DeleteCategoryTranslationRequest) error
 }
 
 type ProductService interface {
 	CreateProduct(ctx context.Context, shop *entity.CreateProductRequest) (*entity.CreateProductResponse, error)
 	GetDetailProduct(ctx context.Context, shop *entity.GetProductDetailRequest) (*entity.GetProductDetailResponse, error)
+	GetProductBySlug(ctx context.Context, req *entity.GetProductBySlugRequest) (*entity.GetProductDetailResponse, error)
 	UpdateProduct(ctx context.Context, shop *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error)
 	DeleteProduct(ctx context.Context, shop *entity.DeleteProductRequest) (*entity.DeleteProductResponse, error)
 	GetProducts(ctx context.Context, shop *entity.GetProductsRequest) (*entity.GetProductsResponse, error)
+	GetSimilarProducts(ctx context.Context, req *entity.GetSimilarProductsRequest) (*entity.GetSimilarProductsResponse, error)
+	GetDuplicateClusters(ctx context.Context, req *entity.GetDuplicateClustersRequest) (*entity.GetDuplicateClustersResponse, error)
+	AuthorizeExport(ctx context.Context, req *entity.ExportProductsRequest) error
+	ExportProducts(ctx context.Context, req *entity.ExportProductsRequest, fn func(item *entity.ExportProductItem) error) error
+	BulkUpdateProducts(ctx context.Context, req *entity.BulkUpdateProductsRequest) (*entity.BulkUpdateProductsResponse, error)
+	SetProductSale(ctx context.Context, req *entity.SetProductSaleRequest) (*entity.ProductSaleResponse, error)
+	DeleteProductSale(ctx context.Context, req *entity.DeleteProductSaleRequest) error
+	GetPriceHistory(ctx context.Context, req *entity.GetPriceHistoryRequest) (*entity.GetPriceHistoryResponse, error)
+	SetPriceTiers(ctx context.Context, req *entity.SetPriceTiersRequest) (*entity.PriceTiersResponse, error)
+	GetPriceQuote(ctx context.Context, req *entity.GetPriceQuoteRequest) (*entity.GetPriceQuoteResponse, error)
+	SetSpecifications(ctx context.Context, req *entity.SetSpecificationsRequest) (*entity.SpecificationsResponse, error)
+	SetBundleItems(ctx context.Context, req *entity.SetBundleItemsRequest) (*entity.BundleItemsResponse, error)
+	GetTranslations(ctx context.Context, req *entity.GetTranslationsRequest) (*entity.GetTranslationsResponse, error)
+	SetTranslation(ctx context.Context, req *entity.SetTranslationRequest) (*entity.Translation, error)
+	DeleteTranslation(ctx context.Context, req *entity.DeleteTranslationRequest) error
+	SetCategoryTranslation(ctx context.Context, req *entity.SetCategoryTranslationRequest) error
+	DeleteCategoryTranslation(ctx context.Context, req *entity.DeleteCategoryTranslationRequest) error
+}
+
+type PricingService interface {
+	// TaxBreakdown splits price, as the seller set it, into net price, tax
+	// and gross price. Without a tax class the whole price is net.
+	TaxBreakdown(price types.Money, class *entity.TaxClassItem, pricesIncludeTax bool) (*entity.PriceBreakdown, error)
+}
+
+// DuplicateDetector applies the near duplicate policy to a product being
+// saved.
+type DuplicateDetector interface {
+	// Check returns the product req is most alike among the near duplicates
+	// in its shop, nil when there is none. Under DuplicatePolicyReject a near
+	// duplicate is a conflict.
+	Check(ctx context.Context, req *entity.DuplicateCheck) (*entity.DuplicateMatch, error)
+	// Flag records the near duplicate a saved product matched, or clears the
+	// flag of a product that no longer is one. It returns the id of the
+	// product it duplicates.
+	Flag(ctx context.Context, id string, match *entity.DuplicateMatch) *string
+}
+
+// ProductModerator screens what sellers write about their products against
+// the banned keywords of the product category.
+type ProductModerator interface {
+	// Screen returns the reasons the content is not allowed, none when it is.
+	Screen(ctx context.Context, content *entity.ModerationContent) ([]string, error)
 }
@@ -3,14 +3,210 @@ package repository
 import (
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
+	"codebase-app/pkg/types"
 	"context"
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 )
 
 var _ ports.ProductRepository = &productRepository{}
 
+// effectivePrice is what a buyer pays right now: the lower of the running
+// flash sale price and, while the sale window is open, the sale price,
+// otherwise the regular price. The sale price is only checked against the
+// price when it is set, so a price lowered later below it wins. The product
+// table must be aliased as p and joined with flashSaleJoin.
+const effectivePrice = `LEAST(fs.campaign_price, CASE
+	WHEN p.sale_price IS NOT NULL AND p.sale_starts_at <= NOW() AND p.sale_ends_at > NOW() THEN LEAST(p.sale_price, p.price)
+	ELSE p.price
+END)`
+
+// flashSaleJoin picks the cheapest running flash sale campaign that still has
+// quota left for p, aliased as fs. Every column is NULL when there is none.
+const flashSaleJoin = `
+	LEFT JOIN LATERAL (
+		SELECT
+			fc.id as campaign_id,
+			fc.name,
+			fc.ends_at,
+			fcp.campaign_price,
+			fcp.quota - fcp.sold as quota_remaining
+		FROM campaign_products fcp
+		JOIN campaigns fc ON fc.id = fcp.campaign_id
+		WHERE
+			fcp.product_id = p.id
+			AND fcp.sold < fcp.quota
+			AND fc.deleted_at IS NULL
+			AND fc.starts_at <= NOW()
+			AND fc.ends_at > NOW()
+		ORDER BY fcp.campaign_price, fc.ends_at
+		LIMIT 1
+	) fs ON true
+`
+
+// flashSaleColumns selects the fs columns in the shape flashSaleDao scans.
+const flashSaleColumns = `
+	fs.campaign_id as flash_sale_id,
+	fs.name as flash_sale_name,
+	fs.campaign_price as flash_sale_price,
+	fs.quota_remaining as flash_sale_quota_remaining,
+	fs.ends_at as flash_sale_ends_at
+`
+
+type flashSaleDao struct {
+	Id             *string      `db:"flash_sale_id"`
+	Name           *string      `db:"flash_sale_name"`
+	Price          *types.Money `db:"flash_sale_price"`
+	QuotaRemaining *int         `db:"flash_sale_quota_remaining"`
+	EndsAt         *time.Time   `db:"flash_sale_ends_at"`
+}
+
+func (d flashSaleDao) item() *entity.FlashSaleItem {
+	if d.Id == nil {
+		return nil
+	}
+
+	return &entity.FlashSaleItem{
+		CampaignId:     *d.Id,
+		Name:           *d.Name,
+		Price:          *d.Price,
+		QuotaRemaining: *d.QuotaRemaining,
+		EndsAt:         *d.EndsAt,
+	}
+}
+
+// ratingJoin joins the review aggregates of p, aliased as prs, which are
+// NULL for products without reviews.
+const ratingJoin = `
+	LEFT JOIN product_rating_stats prs ON prs.product_id = p.id
+`
+
+// ratingColumns selects the prs columns in the shape ratingDao scans.
+const ratingColumns = `
+	COALESCE(prs.rating_count, 0) as rating_count,
+	COALESCE(prs.rating_sum, 0) as rating_sum,
+	COALESCE(prs.rating_1, 0) as rating_1,
+	COALESCE(prs.rating_2, 0) as rating_2,
+	COALESCE(prs.rating_3, 0) as rating_3,
+	COALESCE(prs.rating_4, 0) as rating_4,
+	COALESCE(prs.rating_5, 0) as rating_5
+`
+
+type ratingDao struct {
+	RatingCount int `db:"rating_count"`
+	RatingSum   int `db:"rating_sum"`
+	Rating1     int `db:"rating_1"`
+	Rating2     int `db:"rating_2"`
+	Rating3     int `db:"rating_3"`
+	Rating4     int `db:"rating_4"`
+	Rating5     int `db:"rating_5"`
+}
+
+func (d ratingDao) summary() entity.RatingSummary {
+	return entity.NewRatingSummary(d.RatingCount, d.RatingSum, [5]int{d.Rating1, d.Rating2, d.Rating3, d.Rating4, d.Rating5})
+}
+
+// translationJoin picks, aliased as tr, the translation of p in the first of
+// the locales bound to its ? that has one. Every column is NULL when there is
+// none, so the original text is used. A translation without description
+// shows the original one, see descriptionColumns.
+const translationJoin = `
+	LEFT JOIN LATERAL (
+		SELECT
+			ptr.locale,
+			ptr.name,
+			ptr.description,
+			ptr.description_format,
+			ptr.description_html,
+			ptr.description_excerpt
+		FROM UNNEST(?::text[]) WITH ORDINALITY AS l(locale, ord)
+		JOIN product_translations ptr ON ptr.product_id = p.id AND ptr.locale = l.locale
+		ORDER BY l.ord
+		LIMIT 1
+	) tr ON true
+`
+
+// descriptionColumns selects the description of p, or of its translation tr
+// when that has one, in the shape GetProductDetailResponse scans.
+const descriptionColumns = `
+	COALESCE(tr.description, p.description, '') as description,
+	CASE WHEN tr.description IS NOT NULL THEN tr.description_format ELSE p.description_format END as description_format,
+	COALESCE(CASE WHEN tr.description IS NOT NULL THEN tr.description_html ELSE p.description_html END, '') as description_html
+`
+
+// descriptionExcerpt is the listing card excerpt of p or its translation tr.
+const descriptionExcerpt = `COALESCE(CASE WHEN tr.description IS NOT NULL THEN tr.description_excerpt ELSE p.description_excerpt END, '')`
+
+// activeSaleEndsAt is the end of the running sale, NULL when there is none
+// or the price has since been lowered to the sale price or below.
+const activeSaleEndsAt = `CASE
+	WHEN p.sale_price IS NOT NULL AND p.sale_price < p.price AND p.sale_starts_at <= NOW() AND p.sale_ends_at > NOW() THEN p.sale_ends_at
+END`
+
+// lowestPrice30Days is the lowest regular price of p in the last 30 days:
+// the price in effect when the window opened and every change since.
+const lowestPrice30Days = `COALESCE((
+	SELECT MIN(h.price)
+	FROM product_price_history h
+	WHERE
+		h.product_id = p.id
+		AND (
+			h.changed_at > NOW() - INTERVAL '30 days'
+			OR h.id = (
+				SELECT h2.id
+				FROM product_price_history h2
+				WHERE h2.product_id = p.id AND h2.changed_at <= NOW() - INTERVAL '30 days'
+				ORDER BY h2.changed_at DESC, h2.id DESC
+				LIMIT 1
+			)
+		)
+), p.price)`
+
+// AvailableStock is the stock of p. A bundle has none of its own: it has as
+// many as its components make complete sets, none when one was deleted or is
+// not active. It is exported for the modules that select the stock of a
+// product with their own query.
+const AvailableStock = `CASE WHEN p.type = 'bundle' THEN COALESCE((
+	SELECT MIN(CASE WHEN c.deleted_at IS NULL AND c.status = 'active' THEN c.stock / bi.quantity ELSE 0 END)
+	FROM product_bundle_items bi
+	JOIN product c ON c.id = bi.product_id
+	WHERE bi.bundle_id = p.id
+), 0) ELSE p.stock END`
+
+// freeSlug picks the first of ?, ?-2, ?-3, ... that no other live product of
+// the shop uses or redirects from. It binds the slug, the shop id and the
+// product id, which is NULL for a new product. An empty slug, of a name
+// without letters or digits, becomes "produk".
+const freeSlug = `(
+	SELECT c.slug
+	FROM (SELECT COALESCE(NULLIF(?::text, ''), 'produk') as base, ?::uuid as shop_id, ?::uuid as product_id) b
+	CROSS JOIN generate_series(1, 10000) n
+	CROSS JOIN LATERAL (SELECT CASE WHEN n = 1 THEN b.base ELSE b.base || '-' || n END as slug) c
+	WHERE
+		NOT EXISTS (
+			SELECT 1 FROM product o
+			WHERE o.shop_id = b.shop_id AND o.slug = c.slug AND o.deleted_at IS NULL
+				AND o.id IS DISTINCT FROM b.product_id
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM product_slug_redirects rd
+			JOIN product rp ON rp.id = rd.product_id AND rp.deleted_at IS NULL
+			WHERE rd.shop_id = b.shop_id AND rd.slug = c.slug
+				AND rd.product_id IS DISTINCT FROM b.product_id
+		)
+	ORDER BY n
+	LIMIT 1
+)`
+
 type productRepository struct {
 	db *sqlx.DB
 }
@@ -24,16 +220,58 @@ func NewProductRepository(db *sqlx.DB) *productRepository {
 func (r *productRepository) CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
 	var resp = new(entity.CreateProductResponse)
 	var (
-		query = `INSERT INTO product (name, brand, price, stock, category_id, shop_id) VALUES (?, ?, ?, ?, ?, ?) RETURNING id`
+		// a product with moderation reasons waits in the review queue and
+		// becomes active once approved
+		held = len(req.ModerationReasons) > 0
+		// the components of a bundle, in the order the seller listed them
+		componentIds = make([]string, 0, len(req.BundleItems))
+		quantities   = make([]int64, 0, len(req.BundleItems))
+		query        = `
+			WITH created AS (
+				INSERT INTO product (name, brand, price, stock, category_id, shop_id, slug, description, description_format, description_html, description_excerpt,
+					status, moderation_reasons, moderation_previous_status, moderation_flagged_at, type)
+				VALUES (?, ?, ?, ?, ?, ?, ` + freeSlug + `, NULLIF(?, ''), ?, NULLIF(?, ''), NULLIF(?, ''),
+					CASE WHEN ? THEN 'flagged' ELSE 'active' END, COALESCE(?::text[], '{}'), CASE WHEN ? THEN 'active' END, CASE WHEN ? THEN NOW() END, ?)
+				RETURNING id, price, status
+			), history AS (
+				INSERT INTO product_price_history (product_id, price, changed_by)
+				SELECT id, price, ? FROM created
+			), components AS (
+				INSERT INTO product_bundle_items (bundle_id, product_id, quantity, position)
+				SELECT c.id, i.product_id, i.quantity, i.position - 1
+				FROM created c
+				CROSS JOIN UNNEST(?::uuid[], ?::int[]) WITH ORDINALITY AS i(product_id, quantity, position)
+			)
+			SELECT id, status FROM created`
 	)
 
+	for _, item := range req.BundleItems {
+		componentIds = append(componentIds, item.ProductId)
+		quantities = append(quantities, int64(item.Quantity))
+	}
+
 	err := r.db.QueryRowContext(ctx, r.db.Rebind(query),
 		req.Name,
 		req.Brand,
 		req.Price,
 		req.Stock,
 		req.CategoryId,
-		req.ShopId).Scan(&resp.Id)
+		req.ShopId,
+		req.Slug,
+		req.ShopId,
+		nil,
+		req.Description,
+		req.DescriptionFormat,
+		req.DescriptionHtml,
+		req.DescriptionExcerpt,
+		held,
+		pq.Array(req.ModerationReasons),
+		held,
+		held,
+		req.Type,
+		req.UserId,
+		pq.Array(componentIds),
+		pq.Array(quantities)).Scan(&resp.Id, &resp.Status)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to create product")
 		return nil, err	
@@ -42,22 +280,47 @@ func (r *productRepository) CreateProduct(ctx context.Context, req *entity.Creat
 }
 
 func (r *productRepository) GetDetailProduct(ctx context.Context, req *entity.GetProductDetailRequest) (*entity.GetProductDetailResponse, error) {
-	var resp = new(entity.GetProductDetailResponse)
+	var (
+		resp = new(entity.GetProductDetailResponse)
+		fs   flashSaleDao
+		tax  struct{ code, name, rate sql.NullString }
+		tags pq.StringArray
+		rd   ratingDao
+	)
 	var (
 		query = `SELECT 
 			p.id, 
-			p.name, 
+			COALESCE(tr.name, p.name) as name,
+			p.slug,
+			COALESCE(tr.locale, '') as locale,
 			p.price, 
-			p.stock, 
+			` + effectivePrice + ` as effective_price,
+			` + activeSaleEndsAt + ` as sale_ends_at,
+			` + lowestPrice30Days + ` as lowest_price_30d,
+			` + AvailableStock + ` as stock,
+			p.status,
+			p.type,
 			p.category_id,
-			c.name as category_name, 
-			COALESCE(p.description, '') as description, 
+			COALESCE(ctr.name, c.name) as category_name,
+			` + descriptionColumns + `,
 			COALESCE(p.image_url, '') as image_url,
 			p.shop_id, 
 			shops.name as shop_name,
-			shops.description as shop_description
+			shops.description as shop_description,
+			shops.currency,
+			ARRAY(
+				SELECT t.name FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+				WHERE pt.product_id = p.id ORDER BY t.name
+			) as tags,
+			tc.code as tax_class_code,
+			tc.name as tax_class_name,
+			tc.rate::text as tax_class_rate,
+			COALESCE(sts.prices_include_tax, TRUE) as prices_include_tax,
+			` + ratingColumns + `,
+			` + flashSaleColumns + `
 		FROM 
 			product p 
+		` + flashSaleJoin + `
 		JOIN 
 			category c 
 		ON 
@@ -66,57 +329,188 @@ func (r *productRepository) GetDetailProduct(ctx context.Context, req *entity.Ge
 			shops
 		ON
 			p.shop_id = shops.id
+		LEFT JOIN shop_tax_settings sts ON sts.shop_id = p.shop_id
+		LEFT JOIN tax_classes tc ON tc.id = COALESCE(sts.tax_class_id, c.tax_class_id)
+		` + ratingJoin + `
+		` + translationJoin + `
+		LEFT JOIN LATERAL (
+			SELECT ctr.name
+			FROM UNNEST(?::text[]) WITH ORDINALITY AS l(locale, ord)
+			JOIN category_translations ctr ON ctr.category_id = c.id AND ctr.locale = l.locale
+			ORDER BY l.ord
+			LIMIT 1
+		) ctr ON true
 		WHERE 
 			p.id = ?`
 	)
 
 	err := r.db.QueryRowxContext(
-		ctx, r.db.Rebind(query), req.Id).Scan(
+		ctx, r.db.Rebind(query), pq.Array(req.Locales), pq.Array(req.Locales), req.Id).Scan(
 			&resp.Id,
 			&resp.Name,
+			&resp.Slug,
+			&resp.Locale,
 			&resp.Price,
+			&resp.EffectivePrice,
+			&resp.SaleEndsAt,
+			&resp.LowestPrice30Days,
 			&resp.Stock,
+			&resp.Status,
+			&resp.Type,
 			&resp.Category.Id,
 			&resp.Category.Name,
 			&resp.Description,
+			&resp.DescriptionFormat,
+			&resp.DescriptionHtml,
 			&resp.ImageUrl,
 			&resp.Shop.Id,
 			&resp.Shop.Name,
 			&resp.Shop.Description,
+			&resp.Currency,
+			&tags,
+			&tax.code,
+			&tax.name,
+			&tax.rate,
+			&resp.PricesIncludeTax,
+			&rd.RatingCount,
+			&rd.RatingSum,
+			&rd.Rating1,
+			&rd.Rating2,
+			&rd.Rating3,
+			&rd.Rating4,
+			&rd.Rating5,
+			&fs.Id,
+			&fs.Name,
+			&fs.Price,
+			&fs.QuotaRemaining,
+			&fs.EndsAt,
 		)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::GetDetailProduct - Failed to get product detail")
 		return nil, err
 	}
+	resp.FlashSale = fs.item()
+	resp.Tags = []string(tags)
+	resp.Rating = rd.summary()
+	if tax.code.Valid {
+		resp.TaxClass = &entity.TaxClassItem{Code: tax.code.String, Name: tax.name.String, Rate: tax.rate.String}
+	}
+
+	if err := resp.SetCurrency(resp.Currency); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetDetailProduct - Failed to set currency")
+		return nil, err
+	}
+	resp.ClampSale()
+
 	return resp, nil
 }
 
+// GetProductIdBySlug finds the live product of a shop that has slug, or had
+// it before it was renamed.
+func (r *productRepository) GetProductIdBySlug(ctx context.Context, shopId, slug string) (string, error) {
+	var (
+		id    string
+		query = `
+			SELECT p.id
+			FROM product p
+			WHERE p.shop_id = ? AND p.slug = ? AND p.deleted_at IS NULL
+			UNION ALL
+			SELECT p.id
+			FROM product_slug_redirects rd
+			JOIN product p ON p.id = rd.product_id AND p.deleted_at IS NULL
+			WHERE rd.shop_id = ? AND rd.slug = ?
+			LIMIT 1`
+	)
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), shopId, slug, shopId, slug).Scan(&id)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Str("slug", slug).Msg("repository::GetProductIdBySlug - Failed to get product")
+		return "", err
+	}
+
+	return id, nil
+}
+
 func (r *productRepository) UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error) {
 	var resp = new(entity.UpdateProductResponse)
 	var (
-		query = `UPDATE product 
-			SET name=?, 
-				brand=?,
-				price=?, 
-				stock=?, 
-				category_id=?, 
-				description=?, 
-				image_url=?, 
-				updated_at = NOw() 
-			WHERE id = ? AND shop_id=? 
-			RETURNING id`
+		// moderation reasons flag the product again; an approval restores
+		// the status it had before it was first flagged
+		held  = len(req.ModerationReasons) > 0
+		query = `
+			WITH previous AS (
+				SELECT id, price, slug FROM product WHERE id = ? AND shop_id = ?
+			), updated AS (
+				UPDATE product 
+				SET name=?, 
+					slug = CASE WHEN product.name = ? THEN product.slug ELSE ` + freeSlug + ` END,
+					brand=?,
+					price=?, 
+					stock = CASE WHEN product.type = 'bundle' THEN product.stock ELSE ? END,
+					category_id=?, 
+					description=?, 
+					description_format=?,
+					description_html=NULLIF(?, ''),
+					description_excerpt=NULLIF(?, ''),
+					image_url=?, 
+					status = CASE WHEN ? THEN 'flagged' ELSE product.status END,
+					moderation_reasons = CASE WHEN ? THEN ?::text[] ELSE product.moderation_reasons END,
+					moderation_previous_status = CASE
+						WHEN ? AND product.status NOT IN ('flagged', 'rejected') THEN product.status
+						ELSE product.moderation_previous_status
+					END,
+					moderation_flagged_at = CASE WHEN ? AND product.status <> 'flagged' THEN NOW() ELSE product.moderation_flagged_at END,
+					updated_at = NOw() 
+				WHERE id = ? AND shop_id=? 
+				RETURNING id, price, slug, shop_id, status
+			), history AS (
+				INSERT INTO product_price_history (product_id, price, previous_price, changed_by)
+				SELECT u.id, u.price, pr.price, ?
+				FROM updated u
+				JOIN previous pr ON pr.id = u.id
+				WHERE u.price <> pr.price
+			), redirect AS (
+				INSERT INTO product_slug_redirects (shop_id, slug, product_id)
+				SELECT u.shop_id, pr.slug, u.id
+				FROM updated u
+				JOIN previous pr ON pr.id = u.id
+				WHERE u.slug <> pr.slug
+				ON CONFLICT (shop_id, slug) DO UPDATE SET product_id = EXCLUDED.product_id, created_at = NOW()
+			), reclaimed AS (
+				-- renamed back to a previous name
+				DELETE FROM product_slug_redirects rd
+				USING updated u
+				JOIN previous pr ON pr.id = u.id
+				WHERE rd.shop_id = u.shop_id AND rd.slug = u.slug AND u.slug <> pr.slug
+			)
+			SELECT id, status FROM updated`
 	)
 
 	err := r.db.QueryRowContext(ctx, r.db.Rebind(query),
+		req.Id,
+		req.ShopId,
+		req.Name,
 		req.Name,
+		req.Slug,
+		req.ShopId,
+		req.Id,
 		req.Brand,
 		req.Price,
 		req.Stock,
 		req.CategoryId,
 		req.Description,
+		req.DescriptionFormat,
+		req.DescriptionHtml,
+		req.DescriptionExcerpt,
 		req.ImageUrl,
+		held,
+		held,
+		pq.Array(req.ModerationReasons),
+		held,
+		held,
 		req.Id,
-		req.ShopId).Scan(&resp.Id)
+		req.ShopId,
+		req.UserId).Scan(&resp.Id, &resp.Status)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to update product")
 		return nil, err
@@ -142,6 +536,8 @@ func (r *productRepository) GetProducts(ctx context.Context, req *entity.GetProd
 	type dao struct{
 		TotalData int `db:"total_data"`
 		entity.ProductItem
+		flashSaleDao
+		ratingDao
 	}
 
 	var(
@@ -151,42 +547,55 @@ func (r *productRepository) GetProducts(ctx context.Context, req *entity.GetProd
 			SELECT
 				COUNT(p.id) OVER() as total_data,
 				p.id,
-				p.name,
+				COALESCE(tr.name, p.name) as name,
+				p.slug,
+				COALESCE(tr.locale, '') as locale,
 				p.brand,
 				p.price,
-				p.stock,
+				` + effectivePrice + ` as effective_price,
+				` + activeSaleEndsAt + ` as sale_ends_at,
+				` + AvailableStock + ` as stock,
+				p.status,
+				p.type,
 				p.category_id,
 				p.shop_id,
-				COALESCE(p.description, '') as description,
-				COALESCE(p.image_url, '') as image_url
+				COALESCE(tr.description, p.description, '') as description,
+				` + descriptionExcerpt + ` as description_excerpt,
+				COALESCE(p.image_url, '') as image_url,
+				s.currency,
+				` + ratingColumns + `,
+				` + flashSaleColumns + `
 			FROM
 				product p
+			JOIN shops s ON s.id = p.shop_id
+			` + ratingJoin + `
+			` + flashSaleJoin + `
+			` + translationJoin + `
 			WHERE
-				deleted_at IS NULL
+				p.deleted_at IS NULL
+				AND p.status = 'active'
 		`
-		args []interface{}
+		args = []interface{}{pq.Array(req.Locales)}
 	)
 
-	if req.ProductName != "" {
-		query += " AND p.name ILIKE ?"
-		args = append(args, "%"+req.ProductName+"%")
-		
-	}
-	if req.CategoryId != "" {
-		query += " AND p.category_id = ?"
-		args = append(args, req.CategoryId)
-	}
-	if req.Brand != "" {
-		query += " AND p.brand ILIKE ?"
-		args = append(args, "%"+req.Brand+"%")
-	}
-	if req.MinPrice > 0 {
-		query += " AND p.price >= ?"
-		args = append(args, req.MinPrice)
-	}
-	if req.MaxPrice > 0 {
-		query += " AND p.price <= ?"
-		args = append(args, req.MaxPrice)
+	query, args = productFilter{
+		name:       req.ProductName,
+		brand:      req.Brand,
+		categoryId: req.CategoryId,
+		minPrice:   req.MinPrice,
+		maxPrice:   req.MaxPrice,
+		attributes: req.Attributes,
+		tag:        req.Tag,
+		locales:    req.Locales,
+	}.apply(query, args)
+
+	switch req.Sort {
+	case "price_asc":
+		query += " ORDER BY " + effectivePrice + " ASC, p.id"
+	case "price_desc":
+		query += " ORDER BY " + effectivePrice + " DESC, p.id"
+	default:
+		query += " ORDER BY p.created_at DESC, p.id"
 	}
 
 	// Pagination
@@ -205,10 +614,1415 @@ func (r *productRepository) GetProducts(ctx context.Context, req *entity.GetProd
 	}
 
 	for _, d := range data {
+		d.ProductItem.FlashSale = d.flashSaleDao.item()
+		d.ProductItem.Rating = d.ratingDao.summary()
+		if err := d.ProductItem.SetCurrency(d.ProductItem.Currency); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::GetProducts - Failed to set currency")
+			return nil, err
+		}
+		d.ProductItem.ClampSale()
 		resp.Items = append(resp.Items, d.ProductItem)
 	}
 
 	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
 
 	return resp, nil
-}
\ No newline at end of file
+}
+
+// GetProductsByIds returns the active products among ids in the order of
+// ids. Missing, deleted and inactive products are left out.
+func (r *productRepository) GetProductsByIds(ctx context.Context, ids []string) ([]entity.ProductItem, error) {
+	type dao struct {
+		entity.ProductItem
+		flashSaleDao
+		ratingDao
+	}
+
+	var (
+		resp = make([]entity.ProductItem, 0, len(ids))
+		data = make([]dao, 0, len(ids))
+	)
+
+	if len(ids) == 0 {
+		return resp, nil
+	}
+
+	query := `
+		SELECT
+			p.id,
+			p.name,
+			p.slug,
+			p.brand,
+			p.price,
+			` + effectivePrice + ` as effective_price,
+			` + activeSaleEndsAt + ` as sale_ends_at,
+			` + AvailableStock + ` as stock,
+			p.status,
+			p.type,
+			p.category_id,
+			p.shop_id,
+			COALESCE(p.description, '') as description,
+			COALESCE(p.description_excerpt, '') as description_excerpt,
+			COALESCE(p.image_url, '') as image_url,
+			s.currency,
+			` + ratingColumns + `,
+			` + flashSaleColumns + `
+		FROM
+			product p
+		JOIN shops s ON s.id = p.shop_id
+		` + ratingJoin + `
+		` + flashSaleJoin + `
+		WHERE
+			p.id = ANY(?::uuid[])
+			AND p.deleted_at IS NULL
+			AND p.status = 'active'
+		ORDER BY array_position(?::uuid[], p.id)
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), pq.Array(ids), pq.Array(ids))
+	if err != nil {
+		log.Error().Err(err).Strs("ids", ids).Msg("repository::GetProductsByIds - Failed to get products")
+		return nil, err
+	}
+
+	for _, d := range data {
+		d.ProductItem.FlashSale = d.flashSaleDao.item()
+		d.ProductItem.Rating = d.ratingDao.summary()
+		if err := d.ProductItem.SetCurrency(d.ProductItem.Currency); err != nil {
+			log.Error().Err(err).Strs("ids", ids).Msg("repository::GetProductsByIds - Failed to set currency")
+			return nil, err
+		}
+		d.ProductItem.ClampSale()
+		d.ProductItem.DiscountPercent = entity.DiscountPercent(d.ProductItem.Price, d.ProductItem.EffectivePrice)
+		resp = append(resp, d.ProductItem)
+	}
+
+	return resp, nil
+}
+
+// similarScore weighs how much p is like the product t, from 0 to 1: the
+// same category, the same brand, the trigram similarity of the names and how
+// close the price is, 1 at the same price down to 0 at half or double.
+const similarScore = `(
+	CASE WHEN p.category_id = t.category_id THEN 0.35 ELSE 0 END
+	+ CASE WHEN lower(p.brand) = lower(t.brand) THEN 0.2 ELSE 0 END
+	+ 0.3 * similarity(p.name, t.name)
+	+ 0.15 * GREATEST(0, 1 - ABS(LN(NULLIF(p.price, 0) / NULLIF(t.price, 0))) / LN(2))
+)`
+
+// GetSimilarProducts ranks the active products sharing the category or
+// brand of product id, or with a similar name, most similar first.
+func (r *productRepository) GetSimilarProducts(ctx context.Context, id string, excludeSameShop bool, limit int) ([]entity.SimilarScore, error) {
+	var (
+		resp  = make([]entity.SimilarScore, 0, limit)
+		query = `
+			SELECT p.id, ROUND(` + similarScore + `::numeric, 4) as score
+			FROM product t
+			JOIN product p ON
+				p.id <> t.id
+				AND p.deleted_at IS NULL
+				AND p.status = 'active'
+				AND (p.category_id = t.category_id OR lower(p.brand) = lower(t.brand) OR p.name % t.name)
+			WHERE
+				t.id = ?
+				AND (NOT ? OR p.shop_id <> t.shop_id)
+			ORDER BY score DESC, p.id
+			LIMIT ?`
+	)
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), id, excludeSameShop, limit)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Bool("exclude_same_shop", excludeSameShop).Msg("repository::GetSimilarProducts - Failed to rank similar products")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetSimilarProductsCache returns the cached ranking of GetSimilarProducts,
+// nil when there is none or it expired.
+func (r *productRepository) GetSimilarProductsCache(ctx context.Context, id string, excludeSameShop bool) ([]entity.SimilarScore, error) {
+	var (
+		items []byte
+		query = `
+			SELECT items
+			FROM product_similar_cache
+			WHERE product_id = ? AND exclude_same_shop = ? AND expires_at > NOW()`
+	)
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id, excludeSameShop).Scan(&items)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetSimilarProductsCache - Failed to get cached similar products")
+		return nil, err
+	}
+
+	resp := make([]entity.SimilarScore, 0)
+	if err := json.Unmarshal(items, &resp); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetSimilarProductsCache - Failed to decode cached similar products")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) SetSimilarProductsCache(ctx context.Context, id string, excludeSameShop bool, items []entity.SimilarScore, ttl time.Duration) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO product_similar_cache (product_id, exclude_same_shop, items, expires_at)
+		VALUES (?, ?, ?, NOW() + make_interval(secs => ?))
+		ON CONFLICT (product_id, exclude_same_shop) DO UPDATE
+		SET items = EXCLUDED.items, created_at = NOW(), expires_at = EXCLUDED.expires_at`
+
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(query), id, excludeSameShop, data, ttl.Seconds())
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::SetSimilarProductsCache - Failed to cache similar products")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSimilarProductsCache forgets the rankings of product id, so they are
+// computed again from its current name, brand, category and price.
+func (r *productRepository) DeleteSimilarProductsCache(ctx context.Context, id string) error {
+	query := `DELETE FROM product_similar_cache WHERE product_id = ?`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::DeleteSimilarProductsCache - Failed to delete cached similar products")
+		return err
+	}
+
+	return nil
+}
+
+// duplicateOf holds when p is a near duplicate of the product bound to its
+// ?s: the name, brand, category and price, then DuplicatePriceTolerance
+// and DuplicateNameSimilarity. pg_trgm compares the lower case words of the
+// names, so case and punctuation don't matter; % lets it use the trigram
+// index before the exact similarity is checked.
+const duplicateOf = `
+	p.name % CAST(? AS TEXT)
+	AND lower(p.brand) = lower(CAST(? AS TEXT))
+	AND p.category_id = CAST(? AS UUID)
+	AND ABS(p.price - CAST(? AS DECIMAL)) <= LEAST(p.price, CAST(? AS DECIMAL)) * ?
+	AND similarity(p.name, CAST(? AS TEXT)) >= ?
+`
+
+// FindDuplicates returns the live products of the shop that are near
+// duplicates of req, most alike first.
+func (r *productRepository) FindDuplicates(ctx context.Context, req *entity.DuplicateCheck) ([]entity.DuplicateMatch, error) {
+	var (
+		resp  = make([]entity.DuplicateMatch, 0)
+		query = `
+			SELECT p.id, p.name, similarity(p.name, ?) as score
+			FROM product p
+			WHERE
+				p.shop_id = ?
+				AND p.deleted_at IS NULL
+				AND p.id IS DISTINCT FROM CAST(NULLIF(?, '') AS UUID)
+				AND ` + duplicateOf + `
+			ORDER BY score DESC, p.created_at
+			LIMIT 5`
+	)
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query),
+		req.Name,
+		req.ShopId,
+		req.ExcludeId,
+		req.Name,
+		req.Brand,
+		req.CategoryId,
+		req.Price,
+		req.Price,
+		entity.DuplicatePriceTolerance,
+		req.Name,
+		entity.DuplicateNameSimilarity)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::FindDuplicates - Failed to find duplicates")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetDuplicateFlag flags product id as a duplicate of match, or clears the
+// flag when match is nil.
+func (r *productRepository) SetDuplicateFlag(ctx context.Context, id string, match *entity.DuplicateMatch) error {
+	var (
+		duplicateOf *string
+		score       *float64
+		query       = `
+			UPDATE product
+			SET
+				duplicate_of = ?,
+				duplicate_score = ?,
+				duplicate_flagged_at = CASE
+					WHEN CAST(? AS UUID) IS NULL THEN NULL
+					WHEN duplicate_of IS NOT DISTINCT FROM CAST(? AS UUID) THEN duplicate_flagged_at
+					ELSE NOW()
+				END
+			WHERE id = ?`
+	)
+
+	if match != nil {
+		duplicateOf, score = &match.Id, &match.Score
+	}
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), duplicateOf, score, duplicateOf, duplicateOf, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Any("match", match).Msg("repository::SetDuplicateFlag - Failed to flag duplicate")
+		return err
+	}
+
+	return nil
+}
+
+// GetDuplicatePairs returns every pair of live products of a shop, or of
+// all shops when shopId is empty, that are near duplicates. The older
+// product of a pair is ProductId.
+func (r *productRepository) GetDuplicatePairs(ctx context.Context, shopId string) ([]entity.DuplicatePair, error) {
+	var (
+		resp  = make([]entity.DuplicatePair, 0)
+		query = `
+			SELECT a.id as product_id, p.id as duplicate_id
+			FROM product a
+			JOIN product p ON
+				p.shop_id = a.shop_id
+				AND p.deleted_at IS NULL
+				AND (p.created_at, p.id) > (a.created_at, a.id)
+				AND p.name % a.name
+				AND lower(p.brand) = lower(a.brand)
+				AND p.category_id = a.category_id
+				AND ABS(p.price - a.price) <= LEAST(p.price, a.price) * ?
+				AND similarity(p.name, a.name) >= ?
+			WHERE
+				a.deleted_at IS NULL
+				AND (CAST(NULLIF(?, '') AS UUID) IS NULL OR a.shop_id = CAST(NULLIF(?, '') AS UUID))
+			ORDER BY a.created_at, a.id, p.created_at, p.id`
+	)
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query),
+		entity.DuplicatePriceTolerance,
+		entity.DuplicateNameSimilarity,
+		shopId,
+		shopId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetDuplicatePairs - Failed to get duplicate pairs")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetDuplicateItems returns the products among ids, oldest first.
+func (r *productRepository) GetDuplicateItems(ctx context.Context, ids []string) ([]entity.DuplicateItem, error) {
+	var (
+		resp  = make([]entity.DuplicateItem, 0, len(ids))
+		query = `
+			SELECT p.id, p.shop_id, p.name, p.brand, p.category_id, p.price, s.currency, p.status, p.duplicate_of, p.created_at
+			FROM product p
+			JOIN shops s ON s.id = p.shop_id
+			WHERE p.id = ANY(?::uuid[])
+			ORDER BY p.created_at, p.id`
+	)
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), pq.Array(ids))
+	if err != nil {
+		log.Error().Err(err).Strs("ids", ids).Msg("repository::GetDuplicateItems - Failed to get products")
+		return nil, err
+	}
+
+	for i := range resp {
+		if resp[i].Price, err = resp[i].Price.WithCurrency(resp[i].Currency); err != nil {
+			log.Error().Err(err).Strs("ids", ids).Msg("repository::GetDuplicateItems - Failed to set currency")
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// ExportProducts streams every product of a shop matching the filters to fn,
+// one row at a time, so the full catalog is never held in memory.
+func (r *productRepository) ExportProducts(ctx context.Context, req *entity.ExportProductsRequest, fn func(item *entity.ExportProductItem) error) error {
+	var (
+		query = `
+			SELECT
+				p.id,
+				p.name,
+				p.brand,
+				p.price,
+				s.currency,
+				` + AvailableStock + ` as stock,
+				p.status,
+				p.category_id,
+				c.name as category_name,
+				p.shop_id,
+				s.name as shop_name,
+				COALESCE(p.description, '') as description,
+				p.description_format,
+				COALESCE(p.image_url, '') as image_url
+			FROM
+				product p
+			` + flashSaleJoin + `
+			JOIN category c ON c.id = p.category_id
+			JOIN shops s ON s.id = p.shop_id
+			WHERE
+				p.deleted_at IS NULL
+				AND p.shop_id = ?
+		`
+		args = []interface{}{req.ShopId}
+	)
+
+	if req.Status != "" {
+		query += " AND p.status = ?"
+		args = append(args, req.Status)
+	}
+
+	query, args = productFilter{
+		name:       req.ProductName,
+		brand:      req.Brand,
+		categoryId: req.CategoryId,
+		minPrice:   req.MinPrice,
+		maxPrice:   req.MaxPrice,
+		attributes: req.Attributes,
+		tag:        req.Tag,
+		locales:    req.Locales,
+	}.apply(query, args)
+	query += " ORDER BY p.created_at, p.id"
+
+	rows, err := r.db.QueryxContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ExportProducts - Failed to query products")
+		return err
+	}
+	defer rows.Close()
+
+	item := new(entity.ExportProductItem)
+	for rows.Next() {
+		if err := rows.StructScan(item); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::ExportProducts - Failed to scan product")
+			return err
+		}
+		if item.Price, err = item.Price.WithCurrency(item.Currency); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::ExportProducts - Failed to set currency")
+			return err
+		}
+
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ExportProducts - Failed to iterate products")
+		return err
+	}
+
+	return nil
+}
+
+// productFilter holds the listing filters shared by GetProducts and
+// ExportProducts. The product table must be aliased as p.
+type productFilter struct {
+	name       string
+	brand      string
+	categoryId string
+	minPrice   types.Money
+	maxPrice   types.Money
+	attributes []entity.AttributeFilter
+	tag        string
+	// locales are the translations the name filter also matches
+	locales []string
+}
+
+func (f productFilter) apply(query string, args []interface{}) (string, []interface{}) {
+	if f.name != "" && len(f.locales) > 0 {
+		query += ` AND (p.name ILIKE ? OR EXISTS (
+			SELECT 1 FROM product_translations ptr
+			WHERE ptr.product_id = p.id AND ptr.locale = ANY(?) AND ptr.name ILIKE ?
+		))`
+		args = append(args, "%"+f.name+"%", pq.Array(f.locales), "%"+f.name+"%")
+	} else if f.name != "" {
+		query += " AND p.name ILIKE ?"
+		args = append(args, "%"+f.name+"%")
+	}
+	if f.categoryId != "" {
+		query += " AND p.category_id = ?"
+		args = append(args, f.categoryId)
+	}
+	if f.brand != "" {
+		query += " AND p.brand ILIKE ?"
+		args = append(args, "%"+f.brand+"%")
+	}
+	if f.minPrice.Amount > 0 {
+		query += " AND " + effectivePrice + " >= ?"
+		args = append(args, f.minPrice)
+	}
+	if f.maxPrice.Amount > 0 {
+		query += " AND " + effectivePrice + " <= ?"
+		args = append(args, f.maxPrice)
+	}
+	if f.tag != "" {
+		// a merged tag name finds the products of the tag it was merged into
+		query += ` AND EXISTS (
+			SELECT 1 FROM product_tags pt
+			WHERE pt.product_id = p.id
+				AND pt.tag_id = (SELECT COALESCE(t.merged_into, t.id) FROM tags t WHERE t.name = ?)
+		)`
+		args = append(args, entity.NormalizeTag(f.tag))
+	}
+	for _, attr := range f.attributes {
+		values := make([]string, 0, len(attr.Values))
+		for _, v := range attr.Values {
+			values = append(values, entity.NormalizeSpecValue(v))
+		}
+
+		query += ` AND EXISTS (
+			SELECT 1 FROM product_specifications ps
+			WHERE ps.product_id = p.id AND ps.key = ? AND ps.value_normalized = ANY(?)
+		)`
+		args = append(args, entity.NormalizeSpecKey(attr.Key), pq.Array(values))
+	}
+
+	return query, args
+}
+
+// BulkUpdateProducts applies the changes in a single transaction. Products
+// that do not exist or belong to another user's shop are reported instead of
+// updated; the remaining rows are changed with one UPDATE ... FROM (VALUES).
+func (r *productRepository) BulkUpdateProducts(ctx context.Context, req *entity.BulkUpdateProductsRequest) ([]entity.BulkUpdateProductResult, error) {
+	type dao struct {
+		Id       string `db:"id"`
+		UserId   string `db:"user_id"`
+		Type     string `db:"type"`
+		Currency string `db:"currency"`
+		// SalePrice is only set while a sale is scheduled or running.
+		SalePrice *types.Money `db:"sale_price"`
+		entity.ProductPricing
+	}
+
+	var (
+		ids     = make([]string, 0, len(req.Items))
+		current = make([]dao, 0, len(req.Items))
+		results = make([]entity.BulkUpdateProductResult, 0, len(req.Items))
+	)
+
+	for _, item := range req.Items {
+		ids = append(ids, item.Id)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::BulkUpdateProducts - Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::BulkUpdateProducts - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	query, args, err := sqlx.In(`
+		SELECT
+			p.id, s.user_id, p.type, s.currency, p.price, ` + AvailableStock + ` as stock, p.status,
+			CASE WHEN p.sale_ends_at > NOW() THEN p.sale_price END as sale_price
+		FROM product p
+		JOIN shops s ON s.id = p.shop_id
+		WHERE p.id IN (?) AND p.deleted_at IS NULL
+		FOR UPDATE OF p
+	`, ids)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to build query")
+		return nil, err
+	}
+
+	err = tx.SelectContext(ctx, &current, tx.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to lock products")
+		return nil, err
+	}
+
+	byId := make(map[string]dao, len(current))
+	for _, c := range current {
+		if c.Price, err = c.Price.WithCurrency(c.Currency); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to set currency")
+			return nil, err
+		}
+		if c.SalePrice != nil {
+			if *c.SalePrice, err = c.SalePrice.WithCurrency(c.Currency); err != nil {
+				log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to set currency")
+				return nil, err
+			}
+		}
+		byId[c.Id] = c
+	}
+
+	// the tiers are checked against the new price like SetPriceTiers checks
+	// them against the current one
+	var tierRows []struct {
+		ProductId string `db:"product_id"`
+		entity.PriceTier
+	}
+
+	query, args, err = sqlx.In(`
+		SELECT product_id, min_quantity, max_quantity, price
+		FROM product_price_tiers
+		WHERE product_id IN (?)
+		ORDER BY product_id, min_quantity
+	`, ids)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to build query")
+		return nil, err
+	}
+
+	err = tx.SelectContext(ctx, &tierRows, tx.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to get price tiers")
+		return nil, err
+	}
+
+	tiers := make(map[string][]entity.PriceTier, len(tierRows))
+	for _, row := range tierRows {
+		if row.Price, err = row.Price.WithCurrency(byId[row.ProductId].Currency); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to set currency")
+			return nil, err
+		}
+		tiers[row.ProductId] = append(tiers[row.ProductId], row.PriceTier)
+	}
+
+	var (
+		values []string
+		vargs  []interface{}
+	)
+
+	for _, item := range req.Items {
+		c, ok := byId[item.Id]
+		switch {
+		case !ok:
+			results = append(results, entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultNotFound})
+			continue
+		case c.UserId != req.UserId:
+			results = append(results, entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultForbidden})
+			continue
+		case item.Status != nil && (c.Status == entity.ProductStatusFlagged || c.Status == entity.ProductStatusRejected):
+			// only a moderator takes a product out of the review
+			results = append(results, entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultValidationError, Errors: map[string][]string{
+				"status": {"status produk yang sedang ditinjau tidak dapat diubah."},
+			}})
+			continue
+		case item.Stock != nil && c.Type == entity.ProductTypeBundle:
+			results = append(results, entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultValidationError, Errors: map[string][]string{
+				"stock": {entity.BundleStockMessage},
+			}})
+			continue
+		case item.Price != nil && item.Price.Currency != c.Currency:
+			results = append(results, entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultValidationError, Errors: map[string][]string{
+				"price": {entity.CurrencyMessage(c.Currency)},
+			}})
+			continue
+		case item.Price != nil && c.SalePrice != nil && c.SalePrice.Amount >= item.Price.Amount:
+			results = append(results, entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultValidationError, Errors: map[string][]string{
+				"price": {"harga produk harus lebih dari sale price."},
+			}})
+			continue
+		}
+
+		if item.Price != nil {
+			if errs := entity.ValidatePriceTiers(tiers[item.Id], *item.Price); errs != nil {
+				results = append(results, entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultValidationError, Errors: errs})
+				continue
+			}
+		}
+
+		before := c.ProductPricing
+		after := c.ProductPricing
+		if item.Price != nil {
+			after.Price = *item.Price
+		}
+		if item.Stock != nil {
+			after.Stock = *item.Stock
+		}
+		if item.Status != nil {
+			after.Status = *item.Status
+		}
+
+		values = append(values, "(?::uuid, ?::numeric, ?::int, ?::varchar)")
+		vargs = append(vargs, item.Id, after.Price, after.Stock, after.Status)
+		results = append(results, entity.BulkUpdateProductResult{
+			Id:     item.Id,
+			Result: entity.BulkResultUpdated,
+			Before: &before,
+			After:  &after,
+		})
+	}
+
+	if len(values) > 0 {
+		query = `
+			UPDATE product p
+			SET
+				price = v.price,
+				stock = CASE WHEN p.type = 'bundle' THEN p.stock ELSE v.stock END,
+				status = v.status,
+				updated_at = NOW()
+			FROM (VALUES ` + strings.Join(values, ", ") + `) AS v(id, price, stock, status)
+			WHERE p.id = v.id
+		`
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(query), vargs...)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to update products")
+			return nil, err
+		}
+	}
+
+	values, vargs = values[:0], vargs[:0]
+	for _, result := range results {
+		if result.Before == nil || result.Before.Price == result.After.Price {
+			continue
+		}
+
+		values = append(values, "(?::uuid, ?::numeric, ?::numeric, ?)")
+		vargs = append(vargs, result.Id, result.After.Price, result.Before.Price, req.UserId)
+	}
+
+	if len(values) > 0 {
+		query = `
+			INSERT INTO product_price_history (product_id, price, previous_price, changed_by)
+			VALUES ` + strings.Join(values, ", ")
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(query), vargs...)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to record price history")
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::BulkUpdateProducts - Failed to commit transaction")
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *productRepository) GetShopOwnership(ctx context.Context, shopId string) (*entity.ShopOwnership, error) {
+	var resp = new(entity.ShopOwnership)
+
+	query := `SELECT id, user_id, currency FROM shops WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), shopId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetShopOwnership - Failed to get shop ownership")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetProductOwnership(ctx context.Context, id string) (*entity.ProductOwnership, error) {
+	var resp = new(entity.ProductOwnership)
+
+	query := `
+		SELECT p.id, p.shop_id, s.user_id, p.type, p.price, s.currency, p.status
+		FROM product p
+		JOIN shops s ON s.id = p.shop_id
+		WHERE p.id = ? AND p.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetProductOwnership - Failed to get product ownership")
+		return nil, err
+	}
+
+	if resp.Price, err = resp.Price.WithCurrency(resp.Currency); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetProductOwnership - Failed to set currency")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetProductSale(ctx context.Context, id string) (*entity.ProductSaleResponse, error) {
+	var resp = new(entity.ProductSaleResponse)
+
+	query := `
+		SELECT id, sale_price, sale_starts_at, sale_ends_at
+		FROM product
+		WHERE id = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetProductSale - Failed to get product sale")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) SetProductSale(ctx context.Context, req *entity.SetProductSaleRequest) (*entity.ProductSaleResponse, error) {
+	var resp = new(entity.ProductSaleResponse)
+
+	query := `
+		UPDATE product
+		SET sale_price = ?, sale_starts_at = ?, sale_ends_at = ?, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+		RETURNING id, sale_price, sale_starts_at, sale_ends_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.SalePrice,
+		req.StartsAt,
+		req.EndsAt,
+		req.Id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetProductSale - Failed to set product sale")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) DeleteProductSale(ctx context.Context, req *entity.DeleteProductSaleRequest) error {
+	query := `
+		UPDATE product
+		SET sale_price = NULL, sale_starts_at = NULL, sale_ends_at = NULL, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteProductSale - Failed to delete product sale")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) GetPriceHistory(ctx context.Context, req *entity.GetPriceHistoryRequest) (*entity.GetPriceHistoryResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.PriceHistoryItem
+	}
+
+	var (
+		resp = new(entity.GetPriceHistoryResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.PriceHistoryItem, 0, req.Paginate)
+
+	var currency string
+
+	query := `
+		SELECT s.currency, ` + lowestPrice30Days + `
+		FROM product p
+		JOIN shops s ON s.id = p.shop_id
+		WHERE p.id = ? AND p.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).Scan(&currency, &resp.LowestPrice30Days)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPriceHistory - Failed to get lowest price")
+		return nil, err
+	}
+
+	query = `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			price,
+			previous_price,
+			changed_by,
+			changed_at
+		FROM product_price_history
+		WHERE product_id = ?
+		ORDER BY changed_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err = r.db.SelectContext(ctx, &data, r.db.Rebind(query), req.Id, req.Paginate, (req.Page-1)*req.Paginate)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPriceHistory - Failed to get price history")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.PriceHistoryItem)
+	}
+
+	if err = resp.SetCurrency(currency); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPriceHistory - Failed to set currency")
+		return nil, err
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *productRepository) GetPriceTiers(ctx context.Context, id string) ([]entity.PriceTier, error) {
+	var tiers = make([]entity.PriceTier, 0)
+
+	query := `
+		SELECT min_quantity, max_quantity, price
+		FROM product_price_tiers
+		WHERE product_id = ?
+		ORDER BY min_quantity
+	`
+
+	err := r.db.SelectContext(ctx, &tiers, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetPriceTiers - Failed to get price tiers")
+		return nil, err
+	}
+
+	return tiers, nil
+}
+
+// SetPriceTiers replaces every tier of the product.
+func (r *productRepository) SetPriceTiers(ctx context.Context, req *entity.SetPriceTiersRequest) error {
+	type dao struct {
+		ProductId string `db:"product_id"`
+		entity.PriceTier
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::SetPriceTiers - Failed to begin transaction")
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::SetPriceTiers - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM product_price_tiers WHERE product_id = ?`), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetPriceTiers - Failed to delete price tiers")
+		return err
+	}
+
+	if len(req.Tiers) > 0 {
+		data := make([]dao, 0, len(req.Tiers))
+		for _, tier := range req.Tiers {
+			data = append(data, dao{ProductId: req.Id, PriceTier: tier})
+		}
+
+		query := `
+			INSERT INTO product_price_tiers (product_id, min_quantity, max_quantity, price)
+			VALUES (:product_id, :min_quantity, :max_quantity, :price)
+		`
+
+		_, err = tx.NamedExecContext(ctx, query, data)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::SetPriceTiers - Failed to insert price tiers")
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetPriceTiers - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// specificationDao stores a Specification value in the column of its type.
+type specificationDao struct {
+	ProductId       string         `db:"product_id"`
+	Key             string         `db:"key"`
+	Label           string         `db:"label"`
+	ValueType       string         `db:"value_type"`
+	ValueText       sql.NullString `db:"value_text"`
+	ValueNumber     sql.NullString `db:"value_number"`
+	ValueBoolean    sql.NullBool   `db:"value_boolean"`
+	Unit            string         `db:"unit"`
+	ValueNormalized string         `db:"value_normalized"`
+	Position        int            `db:"position"`
+}
+
+func (d specificationDao) item() entity.Specification {
+	spec := entity.Specification{Key: d.Key, Label: d.Label, Type: d.ValueType, Unit: d.Unit}
+
+	switch d.ValueType {
+	case entity.SpecTypeNumber:
+		n, _ := new(big.Rat).SetString(d.ValueNumber.String)
+		spec.Value = json.Number(entity.FormatSpecNumber(n))
+	case entity.SpecTypeBoolean:
+		spec.Value = d.ValueBoolean.Bool
+	default:
+		spec.Value = d.ValueText.String
+	}
+
+	return spec
+}
+
+func (r *productRepository) GetSpecifications(ctx context.Context, id string) ([]entity.Specification, error) {
+	var data = make([]specificationDao, 0)
+
+	query := `
+		SELECT
+			product_id, key, label, value_type, value_text,
+			value_number::text as value_number, value_boolean, unit, value_normalized, position
+		FROM product_specifications
+		WHERE product_id = ?
+		ORDER BY position, key
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetSpecifications - Failed to get specifications")
+		return nil, err
+	}
+
+	specs := make([]entity.Specification, 0, len(data))
+	for _, d := range data {
+		specs = append(specs, d.item())
+	}
+
+	return specs, nil
+}
+
+// SetSpecifications replaces every specification of the product. Values must
+// already be normalized by the service: string, json.Number or bool.
+func (r *productRepository) SetSpecifications(ctx context.Context, req *entity.SetSpecificationsRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::SetSpecifications - Failed to begin transaction")
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::SetSpecifications - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM product_specifications WHERE product_id = ?`), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetSpecifications - Failed to delete specifications")
+		return err
+	}
+
+	if len(req.Specifications) > 0 {
+		data := make([]specificationDao, 0, len(req.Specifications))
+		for i, spec := range req.Specifications {
+			d := specificationDao{
+				ProductId: req.Id,
+				Key:       spec.Key,
+				Label:     spec.Label,
+				ValueType: spec.Type,
+				Unit:      spec.Unit,
+				Position:  i,
+			}
+
+			switch v := spec.Value.(type) {
+			case json.Number:
+				d.ValueNumber = sql.NullString{String: v.String(), Valid: true}
+				d.ValueNormalized = entity.NormalizeSpecValue(v.String() + spec.Unit)
+			case bool:
+				d.ValueBoolean = sql.NullBool{Bool: v, Valid: true}
+				d.ValueNormalized = strconv.FormatBool(v)
+			case string:
+				d.ValueText = sql.NullString{String: v, Valid: true}
+				d.ValueNormalized = entity.NormalizeSpecValue(strings.TrimSpace(v + " " + spec.Unit))
+			}
+
+			data = append(data, d)
+		}
+
+		query := `
+			INSERT INTO product_specifications (
+				product_id, key, label, value_type, value_text, value_number,
+				value_boolean, unit, value_normalized, position
+			)
+			VALUES (
+				:product_id, :key, :label, :value_type, :value_text, CAST(:value_number AS DECIMAL),
+				:value_boolean, :unit, :value_normalized, :position
+			)
+		`
+
+		_, err = tx.NamedExecContext(ctx, query, data)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::SetSpecifications - Failed to insert specifications")
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetSpecifications - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// ResolveTags creates the tags that do not exist yet and returns one item per
+// name, with merged tags replaced by the tag they were merged into.
+func (r *productRepository) ResolveTags(ctx context.Context, names []string) ([]entity.TagItem, error) {
+	var resp = make([]entity.TagItem, 0, len(names))
+
+	query := `
+		WITH inserted AS (
+			INSERT INTO tags (name)
+			SELECT DISTINCT UNNEST(?::text[])
+			ON CONFLICT (name) DO NOTHING
+			RETURNING id, name, merged_into, banned_at
+		), matched AS (
+			SELECT id, name, merged_into, banned_at FROM tags WHERE name = ANY(?::text[])
+			UNION ALL
+			SELECT id, name, merged_into, banned_at FROM inserted
+		)
+		SELECT
+			COALESCE(m.id, t.id) as id,
+			COALESCE(m.name, t.name) as name,
+			(CASE WHEN m.id IS NULL THEN t.banned_at ELSE m.banned_at END) IS NOT NULL as banned
+		FROM matched t
+		LEFT JOIN tags m ON m.id = t.merged_into
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), pq.Array(names), pq.Array(names))
+	if err != nil {
+		log.Error().Err(err).Strs("names", names).Msg("repository::ResolveTags - Failed to resolve tags")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetProductTags replaces every tag of the product.
+func (r *productRepository) SetProductTags(ctx context.Context, id string, tagIds []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::SetProductTags - Failed to begin transaction")
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::SetProductTags - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM product_tags WHERE product_id = ?`), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::SetProductTags - Failed to delete product tags")
+		return err
+	}
+
+	query := `
+		INSERT INTO product_tags (product_id, tag_id)
+		SELECT ?, UNNEST(?::uuid[])
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), id, pq.Array(tagIds))
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Strs("tag_ids", tagIds).Msg("repository::SetProductTags - Failed to insert product tags")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::SetProductTags - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) GetTranslations(ctx context.Context, id string) ([]entity.Translation, error) {
+	var resp = make([]entity.Translation, 0)
+
+	query := `
+		SELECT
+			locale,
+			name,
+			COALESCE(description, '') as description,
+			description_format,
+			COALESCE(description_html, '') as description_html,
+			updated_at
+		FROM product_translations
+		WHERE product_id = ?
+		ORDER BY locale
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetTranslations - Failed to get product translations")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) SetTranslation(ctx context.Context, req *entity.SetTranslationRequest) (*entity.Translation, error) {
+	var resp = new(entity.Translation)
+
+	query := `
+		INSERT INTO product_translations (product_id, locale, name, description, description_format, description_html, description_excerpt)
+		VALUES (?, ?, ?, NULLIF(?, ''), ?, NULLIF(?, ''), NULLIF(?, ''))
+		ON CONFLICT (product_id, locale) DO UPDATE
+		SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			description_format = EXCLUDED.description_format,
+			description_html = EXCLUDED.description_html,
+			description_excerpt = EXCLUDED.description_excerpt,
+			updated_at = NOW()
+		RETURNING
+			locale,
+			name,
+			COALESCE(description, '') as description,
+			description_format,
+			COALESCE(description_html, '') as description_html,
+			updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Id, req.Locale, req.Name, req.Description, req.DescriptionFormat, req.DescriptionHtml, req.DescriptionExcerpt,
+	).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetTranslation - Failed to set product translation")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) DeleteTranslation(ctx context.Context, req *entity.DeleteTranslationRequest) error {
+	query := `DELETE FROM product_translations WHERE product_id = ? AND locale = ?`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.Locale)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteTranslation - Failed to delete product translation")
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetCategoryTranslation returns sql.ErrNoRows when the category does not
+// exist.
+func (r *productRepository) SetCategoryTranslation(ctx context.Context, req *entity.SetCategoryTranslationRequest) error {
+	query := `
+		INSERT INTO category_translations (category_id, locale, name)
+		SELECT id, ?, ? FROM category WHERE id = ? AND deleted_at IS NULL
+		ON CONFLICT (category_id, locale) DO UPDATE
+		SET name = EXCLUDED.name, updated_at = NOW()
+	`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Locale, req.Name, req.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetCategoryTranslation - Failed to set category translation")
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *productRepository) DeleteCategoryTranslation(ctx context.Context, req *entity.DeleteCategoryTranslationRequest) error {
+	query := `DELETE FROM category_translations WHERE category_id = ? AND locale = ?`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.CategoryId, req.Locale)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategoryTranslation - Failed to delete category translation")
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetBundleComponents returns the components of a bundle in the order the
+// seller listed them, deleted ones with a stock of 0.
+func (r *productRepository) GetBundleComponents(ctx context.Context, id string) ([]entity.BundleComponent, error) {
+	var resp = make([]entity.BundleComponent, 0)
+
+	query := `
+		SELECT
+			bi.product_id,
+			p.name,
+			p.slug,
+			bi.quantity,
+			CASE WHEN p.deleted_at IS NULL THEN p.stock ELSE 0 END as stock,
+			p.status
+		FROM product_bundle_items bi
+		JOIN product p ON p.id = bi.product_id
+		WHERE bi.bundle_id = ?
+		ORDER BY bi.position, bi.product_id
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetBundleComponents - Failed to get bundle components")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetBundleCandidates returns the live products among ids.
+func (r *productRepository) GetBundleCandidates(ctx context.Context, ids []string) ([]entity.BundleCandidate, error) {
+	var resp = make([]entity.BundleCandidate, 0, len(ids))
+
+	query := `SELECT id, shop_id, type FROM product WHERE id = ANY(?::uuid[]) AND deleted_at IS NULL`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), pq.Array(ids))
+	if err != nil {
+		log.Error().Err(err).Strs("ids", ids).Msg("repository::GetBundleCandidates - Failed to get products")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetBundleItems replaces every component of the bundle.
+func (r *productRepository) SetBundleItems(ctx context.Context, req *entity.SetBundleItemsRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::SetBundleItems - Failed to begin transaction")
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::SetBundleItems - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM product_bundle_items WHERE bundle_id = ?`), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetBundleItems - Failed to delete bundle items")
+		return err
+	}
+
+	var (
+		ids        = make([]string, 0, len(req.Items))
+		quantities = make([]int64, 0, len(req.Items))
+	)
+	for _, item := range req.Items {
+		ids = append(ids, item.ProductId)
+		quantities = append(quantities, int64(item.Quantity))
+	}
+
+	query := `
+		INSERT INTO product_bundle_items (bundle_id, product_id, quantity, position)
+		SELECT ?, i.product_id, i.quantity, i.position - 1
+		FROM UNNEST(?::uuid[], ?::int[]) WITH ORDINALITY AS i(product_id, quantity, position)
+	`
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), req.Id, pq.Array(ids), pq.Array(quantities))
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetBundleItems - Failed to create bundle items")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetBundleItems - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// TakeStock takes quantity of a product out of stock. A bundle has no stock
+// of its own: each of its components gives quantity times what a bundle
+// holds, all or none, and a component that was deleted or is not active has
+// none to give. The rows are locked in id order, so purchases of
+// bundles that share components cannot deadlock, and checked once locked,
+// so concurrent purchases can never oversell. It runs in the caller's
+// transaction, which commits or rolls back the stock with the rest of the
+// purchase.
+func (r *productRepository) TakeStock(ctx context.Context, tx *sqlx.Tx, productId string, quantity int) error {
+	type dao struct {
+		Id      string `db:"id"`
+		Stock   int    `db:"stock"`
+		Needed  int64  `db:"needed"`
+		Deleted bool   `db:"deleted"`
+		Active  bool   `db:"active"`
+	}
+
+	var (
+		data   = make([]dao, 0)
+		ids    = make([]string, 0)
+		needed = make([]int64, 0)
+	)
+
+	query := `
+		SELECT
+			c.id, c.stock, COALESCE(bi.quantity, 1) * ? as needed,
+			c.deleted_at IS NOT NULL as deleted, c.status = 'active' as active
+		FROM product p
+		LEFT JOIN product_bundle_items bi ON bi.bundle_id = p.id AND p.type = 'bundle'
+		JOIN product c ON c.id = COALESCE(bi.product_id, p.id)
+		WHERE p.id = ? AND p.deleted_at IS NULL
+		ORDER BY c.id
+		FOR UPDATE OF c
+	`
+
+	err := tx.SelectContext(ctx, &data, tx.Rebind(query), quantity, productId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::TakeStock - Failed to lock product stock")
+		return err
+	}
+
+	if len(data) == 0 {
+		return entity.ErrOutOfStock
+	}
+
+	for _, d := range data {
+		if d.Deleted || !d.Active || int64(d.Stock) < d.Needed {
+			return entity.ErrOutOfStock
+		}
+		ids = append(ids, d.Id)
+		needed = append(needed, d.Needed)
+	}
+
+	query = `
+		UPDATE product c
+		SET stock = c.stock - v.needed, updated_at = NOW()
+		FROM UNNEST(?::uuid[], ?::int[]) AS v(id, needed)
+		WHERE c.id = v.id
+	`
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), pq.Array(ids), pq.Array(needed))
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::TakeStock - Failed to take product stock")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"codebase-app/internal/module/product/entity"
+	"codebase-app/internal/module/product/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"fmt"
+)
+
+var _ ports.DuplicateDetector = &duplicateDetector{}
+
+type duplicateDetector struct {
+	repo   ports.ProductRepository
+	policy entity.DuplicatePolicy
+}
+
+func NewDuplicateDetector(repo ports.ProductRepository, policy entity.DuplicatePolicy) *duplicateDetector {
+	return &duplicateDetector{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+func (d *duplicateDetector) Check(ctx context.Context, req *entity.DuplicateCheck) (*entity.DuplicateMatch, error) {
+	if d.policy == entity.DuplicatePolicyOff {
+		return nil, nil
+	}
+
+	matches, err := d.repo.FindDuplicates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	if d.policy == entity.DuplicatePolicyReject {
+		errs := errmsg.NewCustomErrors(409, errmsg.WithMessage("Produk serupa sudah ada di toko ini"))
+		for _, m := range matches {
+			errs.Add("name", fmt.Sprintf("mirip dengan produk %q (%s).", m.Name, m.Id))
+		}
+		return nil, errs
+	}
+
+	return &matches[0], nil
+}
+
+func (d *duplicateDetector) Flag(ctx context.Context, id string, match *entity.DuplicateMatch) *string {
+	if d.policy == entity.DuplicatePolicyOff {
+		return nil
+	}
+
+	// the product is saved; a failed flag only leaves it out of the report
+	// until its next update
+	_ = d.repo.SetDuplicateFlag(ctx, id, match)
+
+	if match == nil {
+		return nil
+	}
+	return &match.Id
+}
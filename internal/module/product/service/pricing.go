@@ -0,0 +1,52 @@
+package service
+
+import (
+	"codebase-app/internal/module/product/entity"
+	"codebase-app/internal/module/product/ports"
+	"codebase-app/pkg/types"
+	"fmt"
+	"math/big"
+)
+
+var _ ports.PricingService = &pricingService{}
+
+type pricingService struct{}
+
+func NewPricingService() *pricingService {
+	return &pricingService{}
+}
+
+// TaxBreakdown rounds the computed part, the tax for exclusive prices and the
+// net price for inclusive ones, so net plus tax is always the gross price.
+func (s *pricingService) TaxBreakdown(price types.Money, class *entity.TaxClassItem, pricesIncludeTax bool) (*entity.PriceBreakdown, error) {
+	resp := &entity.PriceBreakdown{
+		NetPrice:   price,
+		TaxAmount:  price.Mul(0),
+		GrossPrice: price,
+	}
+
+	if class == nil {
+		return resp, nil
+	}
+
+	rate, ok := new(big.Rat).SetString(class.Rate)
+	if !ok {
+		return nil, fmt.Errorf("invalid tax rate %q for tax class %s", class.Rate, class.Code)
+	}
+	rate.Quo(rate, big.NewRat(100, 1))
+
+	var err error
+	if pricesIncludeTax {
+		// net = gross / (1 + rate)
+		resp.NetPrice = price.MulRat(new(big.Rat).Inv(new(big.Rat).Add(big.NewRat(1, 1), rate)))
+		resp.TaxAmount, err = price.Sub(resp.NetPrice)
+	} else {
+		resp.TaxAmount = price.MulRat(rate)
+		resp.GrossPrice, err = price.Add(resp.TaxAmount)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
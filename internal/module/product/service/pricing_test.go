@@ -0,0 +1,86 @@
+package service
+
+import (
+	"codebase-app/internal/module/product/entity"
+	"codebase-app/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxBreakdown(t *testing.T) {
+	var (
+		s    = NewPricingService()
+		ppn  = &entity.TaxClassItem{Code: "PPN", Rate: "11.00"}
+		half = &entity.TaxClassItem{Code: "HALF", Rate: "12.5"}
+		zero = &entity.TaxClassItem{Code: "ZERO", Rate: "0.00"}
+		idr  = func(amount int64) types.Money { return types.NewMoney(amount, "IDR") }
+	)
+
+	tests := []struct {
+		name        string
+		price       int64
+		class       *entity.TaxClassItem
+		includesTax bool
+		net         int64
+		tax         int64
+		gross       int64
+	}{
+		{name: "no tax class", price: 1001, net: 1001, tax: 0, gross: 1001},
+		{name: "exclusive 11%", price: 150000, class: ppn, net: 150000, tax: 16500, gross: 166500},
+		{name: "exclusive 11% rounds the tax down", price: 1001, class: ppn, net: 1001, tax: 110, gross: 1111},
+		{name: "exclusive 11% rounds the tax up", price: 1005, class: ppn, net: 1005, tax: 111, gross: 1116},
+		{name: "exclusive rounds half away from zero", price: 4, class: half, net: 4, tax: 1, gross: 5},
+		{name: "inclusive 11%", price: 166500, class: ppn, includesTax: true, net: 150000, tax: 16500, gross: 166500},
+		{name: "inclusive 11% rounds the net price up", price: 1111, class: ppn, includesTax: true, net: 1001, tax: 110, gross: 1111},
+		{name: "inclusive 11% on an odd amount", price: 1001, class: ppn, includesTax: true, net: 902, tax: 99, gross: 1001},
+		{name: "inclusive rounds the net price down", price: 5, class: half, includesTax: true, net: 4, tax: 1, gross: 5},
+		{name: "exclusive 0%", price: 1001, class: zero, net: 1001, tax: 0, gross: 1001},
+		{name: "inclusive 0%", price: 1001, class: zero, includesTax: true, net: 1001, tax: 0, gross: 1001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.TaxBreakdown(idr(tt.price), tt.class, tt.includesTax)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, idr(tt.net), resp.NetPrice)
+			assert.Equal(t, idr(tt.tax), resp.TaxAmount)
+			assert.Equal(t, idr(tt.gross), resp.GrossPrice)
+		})
+	}
+}
+
+func TestTaxBreakdownAddsUp(t *testing.T) {
+	s := NewPricingService()
+
+	for _, class := range []*entity.TaxClassItem{
+		{Code: "PPN", Rate: "11.00"},
+		{Code: "HALF", Rate: "12.5"},
+		{Code: "ZERO", Rate: "0.00"},
+	} {
+		for _, includesTax := range []bool{true, false} {
+			for amount := int64(1); amount <= 2000; amount++ {
+				resp, err := s.TaxBreakdown(types.NewMoney(amount, "IDR"), class, includesTax)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				sum, err := resp.NetPrice.Add(resp.TaxAmount)
+				if !assert.NoError(t, err) {
+					return
+				}
+				if !assert.Equal(t, resp.GrossPrice, sum, "%s, includes tax %t, amount %d", class.Code, includesTax, amount) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestTaxBreakdownInvalidRate(t *testing.T) {
+	_, err := NewPricingService().TaxBreakdown(types.NewMoney(1000, "IDR"), &entity.TaxClassItem{Code: "BAD", Rate: "abc"}, false)
+	assert.EqualError(t, err, `invalid tax rate "abc" for tax class BAD`)
+}
@@ -1,39 +1,1165 @@
 package service
 
 import (
+	"codebase-app/internal/adapter"
+	auditEntity "codebase-app/internal/module/audit/entity"
+	auditPorts "codebase-app/internal/module/audit/ports"
+	exchangeRateEntity "codebase-app/internal/module/exchange_rate/entity"
+	exchangeRatePorts "codebase-app/internal/module/exchange_rate/ports"
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
+	"codebase-app/pkg"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/richtext"
+	"codebase-app/pkg/types"
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
 )
 
 var _ ports.ProductService = &productService{}
 
 type productService struct {
-	repo ports.ProductRepository
+	repo      ports.ProductRepository
+	audit     auditPorts.AuditService
+	validator adapter.Validator
+	rates     exchangeRatePorts.ExchangeRateService
+	pricing   ports.PricingService
+	// duplicates is applied to near duplicates on create and update.
+	duplicates ports.DuplicateDetector
+	moderator  ports.ProductModerator
 }
 
-func NewProductService(repo ports.ProductRepository) *productService {
+func NewProductService(repo ports.ProductRepository, audit auditPorts.AuditService, v adapter.Validator, rates exchangeRatePorts.ExchangeRateService, pricing ports.PricingService, duplicates ports.DuplicateDetector, moderator ports.ProductModerator) *productService {
 	return &productService{
-		repo: repo,
+		repo:       repo,
+		audit:      audit,
+		validator:  v,
+		rates:      rates,
+		pricing:    pricing,
+		duplicates: duplicates,
+		moderator:  moderator,
 	}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
-	return s.repo.CreateProduct(ctx, req)
+	tagIds, err := s.resolveTags(ctx, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	req.DescriptionFormat, req.DescriptionHtml, req.DescriptionExcerpt, err = renderDescription(req.DescriptionFormat, req.Description)
+	if err != nil {
+		return nil, err
+	}
+	req.Slug = pkg.Slugify(req.Name)
+
+	shop, err := s.repo.GetShopOwnership(ctx, req.ShopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCurrency(shop.Currency, "price", req.Price); err != nil {
+		return nil, err
+	}
+
+	req.Type = entity.ProductTypeSingle
+	if len(req.BundleItems) > 0 {
+		if req.Stock != 0 {
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("stock", entity.BundleStockMessage))
+		}
+		if err := s.checkBundleItems(ctx, req.ShopId, "", "bundle_items", req.BundleItems); err != nil {
+			return nil, err
+		}
+		req.Type = entity.ProductTypeBundle
+	}
+
+	duplicate, err := s.duplicates.Check(ctx, &entity.DuplicateCheck{
+		ShopId:     req.ShopId,
+		Name:       req.Name,
+		Brand:      req.Brand,
+		CategoryId: req.CategoryId,
+		Price:      req.Price,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req.ModerationReasons, err = s.moderator.Screen(ctx, &entity.ModerationContent{
+		CategoryId:  req.CategoryId,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.CreateProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.DuplicateOf = s.duplicates.Flag(ctx, resp.Id, duplicate)
+	resp.ModerationReasons = req.ModerationReasons
+
+	if len(tagIds) > 0 {
+		if err := s.repo.SetProductTags(ctx, resp.Id, tagIds); err != nil {
+			return nil, err
+		}
+	}
+
+	after, _ := s.repo.GetDetailProduct(ctx, &entity.GetProductDetailRequest{Id: resp.Id})
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: resp.Id,
+		Action:   auditEntity.ActionCreate,
+		After:    after,
+	})
+
+	return resp, nil
 }
 
 func (s *productService) GetDetailProduct(ctx context.Context, req *entity.GetProductDetailRequest) (*entity.GetProductDetailResponse, error) {
-	return s.repo.GetDetailProduct(ctx, req)
+	if _, err := s.authorizeView(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.GetDetailProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.DiscountPercent = entity.DiscountPercent(resp.Price, resp.EffectivePrice)
+
+	resp.PriceTiers, err = s.getPriceTiers(ctx, resp.Id, resp.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.PriceBreakdown, err = s.pricing.TaxBreakdown(resp.EffectivePrice, resp.TaxClass, resp.PricesIncludeTax)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Specifications, err = s.repo.GetSpecifications(ctx, resp.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Type == entity.ProductTypeBundle {
+		resp.BundleItems, err = s.repo.GetBundleComponents(ctx, resp.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Currency != "" && req.Currency != resp.Currency {
+		rate, err := s.rate(ctx, resp.Currency, req.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.ConvertedPrice, err = entity.NewConvertedPrice(req.Currency, rate, resp.Price, resp.EffectivePrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// GetProductBySlug returns the product the slug of a shop points to. A
+// previous slug finds the renamed product, whose current Slug differs from
+// the requested one.
+func (s *productService) GetProductBySlug(ctx context.Context, req *entity.GetProductBySlugRequest) (*entity.GetProductDetailResponse, error) {
+	id, err := s.repo.GetProductIdBySlug(ctx, req.ShopId, req.Slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetDetailProduct(ctx, &entity.GetProductDetailRequest{
+		UserId:   req.UserId,
+		Id:       id,
+		Currency: req.Currency,
+		Locales:  req.Locales,
+	})
+}
+
+// GetDuplicateClusters groups the near duplicate products of a shop, or of
+// all shops, largest groups first.
+func (s *productService) GetDuplicateClusters(ctx context.Context, req *entity.GetDuplicateClustersRequest) (*entity.GetDuplicateClustersResponse, error) {
+	pairs, err := s.repo.GetDuplicatePairs(ctx, req.ShopId)
+	if err != nil {
+		return nil, err
+	}
+
+	// union-find over the pairs; clusters keep the order their first
+	// product appears in
+	var (
+		parent = make(map[string]string)
+		order  = make([]string, 0)
+	)
+	find := func(id string) string {
+		for parent[id] != id {
+			parent[id] = parent[parent[id]]
+			id = parent[id]
+		}
+		return id
+	}
+	for _, pair := range pairs {
+		for _, id := range []string{pair.ProductId, pair.DuplicateId} {
+			if _, ok := parent[id]; !ok {
+				parent[id] = id
+				order = append(order, id)
+			}
+		}
+
+		a, b := find(pair.ProductId), find(pair.DuplicateId)
+		if a != b {
+			parent[b] = a
+		}
+	}
+
+	var (
+		members = make(map[string][]string)
+		roots   = make([]string, 0)
+	)
+	for _, id := range order {
+		root := find(id)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], id)
+	}
+	slices.SortStableFunc(roots, func(a, b string) int {
+		return len(members[b]) - len(members[a])
+	})
+
+	resp := &entity.GetDuplicateClustersResponse{Items: make([]entity.DuplicateCluster, 0, req.Paginate)}
+	resp.Meta.TotalData = len(roots)
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	start := min((req.Page-1)*req.Paginate, len(roots))
+	page := roots[start:min(start+req.Paginate, len(roots))]
+
+	ids := make([]string, 0)
+	for _, root := range page {
+		ids = append(ids, members[root]...)
+	}
+
+	items, err := s.repo.GetDuplicateItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := make(map[string]*entity.DuplicateCluster, len(page))
+	for _, root := range page {
+		resp.Items = append(resp.Items, entity.DuplicateCluster{Items: make([]entity.DuplicateItem, 0, len(members[root]))})
+		cluster[root] = &resp.Items[len(resp.Items)-1]
+	}
+	for _, item := range items {
+		c := cluster[find(item.Id)]
+		c.ShopId = item.ShopId
+		c.Items = append(c.Items, item)
+	}
+
+	return resp, nil
+}
+
+// GetSimilarProducts returns the products most like product req.Id. The
+// ranking is cached per product for entity.SimilarProductsCacheTTL; the
+// products themselves are read fresh, so prices, stock and status are
+// current.
+func (s *productService) GetSimilarProducts(ctx context.Context, req *entity.GetSimilarProductsRequest) (*entity.GetSimilarProductsResponse, error) {
+	_, err := s.authorizeView(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.repo.GetSimilarProductsCache(ctx, req.Id, req.ExcludeSameShop)
+	if err != nil {
+		return nil, err
+	}
+
+	if scores == nil {
+		scores, err = s.repo.GetSimilarProducts(ctx, req.Id, req.ExcludeSameShop, entity.MaxSimilarProducts)
+		if err != nil {
+			return nil, err
+		}
+
+		// without the cache the next request ranks them again
+		_ = s.repo.SetSimilarProductsCache(ctx, req.Id, req.ExcludeSameShop, scores, entity.SimilarProductsCacheTTL)
+	}
+
+	var (
+		ids   = make([]string, 0, len(scores))
+		score = make(map[string]float64, len(scores))
+		resp  = &entity.GetSimilarProductsResponse{Items: make([]entity.SimilarProductItem, 0, req.Limit)}
+	)
+
+	for _, sc := range scores {
+		ids = append(ids, sc.Id)
+		score[sc.Id] = sc.Score
+	}
+
+	// products that are no longer active are left out, so all ranked ids
+	// are read to fill the limit
+	items, err := s.repo.GetProductsByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if len(resp.Items) == req.Limit {
+			break
+		}
+		resp.Items = append(resp.Items, entity.SimilarProductItem{ProductItem: item, Score: score[item.Id]})
+	}
+
+	return resp, nil
 }
 
 func (s *productService) UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error) {
-	return s.repo.UpdateProduct(ctx, req)
+	tagIds, err := s.resolveTags(ctx, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	req.DescriptionFormat, req.DescriptionHtml, req.DescriptionExcerpt, err = renderDescription(req.DescriptionFormat, req.Description)
+	if err != nil {
+		return nil, err
+	}
+	req.Slug = pkg.Slugify(req.Name)
+
+	shop, err := s.repo.GetShopOwnership(ctx, req.ShopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCurrency(shop.Currency, "price", req.Price); err != nil {
+		return nil, err
+	}
+
+	duplicate, err := s.duplicates.Check(ctx, &entity.DuplicateCheck{
+		ShopId:     req.ShopId,
+		ExcludeId:  req.Id,
+		Name:       req.Name,
+		Brand:      req.Brand,
+		CategoryId: req.CategoryId,
+		Price:      req.Price,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req.ModerationReasons, err = s.moderator.Screen(ctx, &entity.ModerationContent{
+		CategoryId:  req.CategoryId,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	before, _ := s.repo.GetDetailProduct(ctx, &entity.GetProductDetailRequest{Id: req.Id})
+	bundle := before != nil && before.Type == entity.ProductTypeBundle
+	switch {
+	case bundle && req.Stock != 0:
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("stock", entity.BundleStockMessage))
+	case !bundle && req.Stock == 0:
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("stock", "stock harus diisi."))
+	}
+	if len(req.ModerationReasons) == 0 && before != nil && before.Status == entity.ProductStatusRejected {
+		// the seller fixed a rejected product, a moderator has to look again
+		req.ModerationReasons = []string{entity.ModerationReasonResubmitted}
+	}
+
+	resp, err := s.repo.UpdateProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.DuplicateOf = s.duplicates.Flag(ctx, resp.Id, duplicate)
+	resp.ModerationReasons = req.ModerationReasons
+
+	if req.Tags != nil {
+		if err := s.repo.SetProductTags(ctx, resp.Id, tagIds); err != nil {
+			return nil, err
+		}
+	}
+
+	// the rankings are computed again on the next request; a failure only
+	// keeps the old one until it expires
+	_ = s.repo.DeleteSimilarProductsCache(ctx, resp.Id)
+
+	after, _ := s.repo.GetDetailProduct(ctx, &entity.GetProductDetailRequest{Id: resp.Id})
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: resp.Id,
+		Action:   auditEntity.ActionUpdate,
+		Before:   before,
+		After:    after,
+	})
+
+	return resp, nil
 }
 
 func (s *productService) DeleteProduct(ctx context.Context, req *entity.DeleteProductRequest) (*entity.DeleteProductResponse, error) {
-	return s.repo.DeleteProduct(ctx, req)
+	before, _ := s.repo.GetDetailProduct(ctx, &entity.GetProductDetailRequest{Id: req.Id})
+
+	resp, err := s.repo.DeleteProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: resp.Id,
+		Action:   auditEntity.ActionDelete,
+		Before:   before,
+	})
+
+	return resp, nil
 }
 
 func (s *productService) GetProducts(ctx context.Context, req *entity.GetProductsRequest) (*entity.GetProductsResponse, error) {
-	return s.repo.GetProducts(ctx, req)
-}
\ No newline at end of file
+	resp, err := s.repo.GetProducts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// items of the same shop currency share one rate lookup
+	rates := make(map[string]*big.Rat)
+
+	for i := range resp.Items {
+		item := &resp.Items[i]
+		item.DiscountPercent = entity.DiscountPercent(item.Price, item.EffectivePrice)
+
+		if req.Currency == "" || req.Currency == item.Currency {
+			continue
+		}
+
+		rate, ok := rates[item.Currency]
+		if !ok {
+			rate, err = s.rate(ctx, item.Currency, req.Currency)
+			if err != nil {
+				return nil, err
+			}
+			rates[item.Currency] = rate
+		}
+
+		item.ConvertedPrice, err = entity.NewConvertedPrice(req.Currency, rate, item.Price, item.EffectivePrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// AuthorizeExport checks the caller owns the shop. It is separate from
+// ExportProducts because errors cannot be returned once streaming started.
+func (s *productService) AuthorizeExport(ctx context.Context, req *entity.ExportProductsRequest) error {
+	_, err := s.authorizeShop(ctx, req.ShopId, req.UserId)
+	return err
+}
+
+func (s *productService) ExportProducts(ctx context.Context, req *entity.ExportProductsRequest, fn func(item *entity.ExportProductItem) error) error {
+	return s.repo.ExportProducts(ctx, req, fn)
+}
+
+// BulkUpdateProducts validates every item on its own so one bad item does
+// not fail the whole request. The response keeps the order of the request.
+func (s *productService) BulkUpdateProducts(ctx context.Context, req *entity.BulkUpdateProductsRequest) (*entity.BulkUpdateProductsResponse, error) {
+	var (
+		resp    = new(entity.BulkUpdateProductsResponse)
+		results = make(map[int]entity.BulkUpdateProductResult, len(req.Items))
+		valid   = &entity.BulkUpdateProductsRequest{UserId: req.UserId}
+		seen    = make(map[string]bool, len(req.Items))
+		index   = make(map[string]int, len(req.Items))
+	)
+
+	for i, item := range req.Items {
+		item := item
+		if err := s.validator.Validate(&item); err != nil {
+			_, errs := errmsg.Errors(err, &item)
+			fieldErrs, _ := errs.(map[string][]string)
+			results[i] = entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultValidationError, Errors: fieldErrs}
+			continue
+		}
+		if item.Price == nil && item.Stock == nil && item.Status == nil {
+			results[i] = entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultValidationError, Errors: map[string][]string{
+				"item": {"minimal salah satu dari price, stock, atau status harus diisi."},
+			}}
+			continue
+		}
+		if seen[item.Id] {
+			results[i] = entity.BulkUpdateProductResult{Id: item.Id, Result: entity.BulkResultValidationError, Errors: map[string][]string{
+				"id": {"id duplikat dalam permintaan."},
+			}}
+			continue
+		}
+
+		seen[item.Id] = true
+		index[item.Id] = i
+		valid.Items = append(valid.Items, item)
+	}
+
+	if len(valid.Items) > 0 {
+		updated, err := s.repo.BulkUpdateProducts(ctx, valid)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range updated {
+			results[index[result.Id]] = result
+
+			if result.Result != entity.BulkResultUpdated {
+				continue
+			}
+			s.audit.Record(ctx, &auditEntity.RecordRequest{
+				ActorId:  req.UserId,
+				Entity:   auditEntity.EntityProduct,
+				EntityId: result.Id,
+				Action:   auditEntity.ActionUpdate,
+				Before:   result.Before,
+				After:    result.After,
+			})
+		}
+	}
+
+	resp.Items = make([]entity.BulkUpdateProductResult, 0, len(req.Items))
+	for i := range req.Items {
+		result := results[i]
+		if result.Result == entity.BulkResultUpdated {
+			resp.Updated++
+		} else {
+			resp.Failed++
+		}
+		resp.Items = append(resp.Items, result)
+	}
+
+	return resp, nil
+}
+
+func (s *productService) SetProductSale(ctx context.Context, req *entity.SetProductSaleRequest) (*entity.ProductSaleResponse, error) {
+	product, err := s.authorizeProduct(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCurrency(product.Currency, "sale_price", req.SalePrice); err != nil {
+		return nil, err
+	}
+	if cmp, err := req.SalePrice.Cmp(product.Price); err != nil || cmp >= 0 {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("sale_price", "sale price harus kurang dari harga produk."))
+	}
+
+	before, _ := s.repo.GetProductSale(ctx, req.Id)
+
+	resp, err := s.repo.SetProductSale(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: req.Id,
+		Action:   auditEntity.ActionUpdate,
+		Before:   before,
+		After:    resp,
+	})
+
+	return resp, nil
+}
+
+func (s *productService) DeleteProductSale(ctx context.Context, req *entity.DeleteProductSaleRequest) error {
+	if _, err := s.authorizeProduct(ctx, req.Id, req.UserId); err != nil {
+		return err
+	}
+
+	before, _ := s.repo.GetProductSale(ctx, req.Id)
+
+	if err := s.repo.DeleteProductSale(ctx, req); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: req.Id,
+		Action:   auditEntity.ActionUpdate,
+		Before:   before,
+		After:    &entity.ProductSaleResponse{Id: req.Id},
+	})
+
+	return nil
+}
+
+func (s *productService) GetPriceHistory(ctx context.Context, req *entity.GetPriceHistoryRequest) (*entity.GetPriceHistoryResponse, error) {
+	if _, err := s.authorizeView(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.GetPriceHistory(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *productService) SetPriceTiers(ctx context.Context, req *entity.SetPriceTiersRequest) (*entity.PriceTiersResponse, error) {
+	product, err := s.authorizeProduct(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, tier := range req.Tiers {
+		if err := checkCurrency(product.Currency, fmt.Sprintf("tiers[%d].price", i), tier.Price); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(req.Tiers, func(a, b entity.PriceTier) int { return a.MinQuantity - b.MinQuantity })
+
+	if errs := entity.ValidatePriceTiers(req.Tiers, product.Price); errs != nil {
+		err := errmsg.NewCustomErrors(400)
+		err.Errors = errs
+		return nil, err
+	}
+
+	before, _ := s.repo.GetPriceTiers(ctx, req.Id)
+
+	if err := s.repo.SetPriceTiers(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resp := &entity.PriceTiersResponse{Id: req.Id, Tiers: req.Tiers}
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: req.Id,
+		Action:   auditEntity.ActionUpdate,
+		Before:   &entity.PriceTiersResponse{Id: req.Id, Tiers: before},
+		After:    resp,
+	})
+
+	return resp, nil
+}
+
+// GetPriceQuote prices quantity units of a product. The tier price only
+// applies when it beats the current effective price.
+func (s *productService) GetPriceQuote(ctx context.Context, req *entity.GetPriceQuoteRequest) (*entity.GetPriceQuoteResponse, error) {
+	if _, err := s.authorizeView(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	product, err := s.repo.GetDetailProduct(ctx, &entity.GetProductDetailRequest{Id: req.Id})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tiers, err := s.getPriceTiers(ctx, req.Id, product.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &entity.GetPriceQuoteResponse{
+		Id:        req.Id,
+		Quantity:  req.Quantity,
+		UnitPrice: product.EffectivePrice,
+	}
+
+	for i := range tiers {
+		tier := &tiers[i]
+		if req.Quantity < tier.MinQuantity || (tier.MaxQuantity != nil && req.Quantity > *tier.MaxQuantity) {
+			continue
+		}
+
+		if cmp, err := tier.Price.Cmp(resp.UnitPrice); err == nil && cmp < 0 {
+			resp.UnitPrice = tier.Price
+			resp.Tier = tier
+		}
+		break
+	}
+
+	resp.TotalPrice = resp.UnitPrice.Mul(int64(req.Quantity))
+
+	resp.PriceBreakdown, err = s.pricing.TaxBreakdown(resp.TotalPrice, product.TaxClass, product.PricesIncludeTax)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetSpecifications replaces the product specifications. Keys are
+// normalized and must be unique; values must match their type.
+func (s *productService) SetSpecifications(ctx context.Context, req *entity.SetSpecificationsRequest) (*entity.SpecificationsResponse, error) {
+	if _, err := s.authorizeProduct(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	var (
+		errs = errmsg.NewCustomErrors(400)
+		keys = make(map[string]bool, len(req.Specifications))
+	)
+
+	for i := range req.Specifications {
+		var (
+			spec  = &req.Specifications[i]
+			field = fmt.Sprintf("specifications[%d]", i)
+		)
+
+		if spec.Label == "" {
+			spec.Label = strings.TrimSpace(spec.Key)
+		}
+		spec.Key = entity.NormalizeSpecKey(spec.Key)
+		spec.Unit = strings.TrimSpace(spec.Unit)
+
+		if keys[spec.Key] {
+			errs.Add(field+".key", "key sudah digunakan pada spesifikasi lain.")
+		}
+		keys[spec.Key] = true
+
+		value, ok := specValue(spec.Type, spec.Value)
+		if !ok {
+			errs.Add(field+".value", fmt.Sprintf("value harus bertipe %s.", spec.Type))
+			continue
+		}
+		spec.Value = value
+	}
+
+	if errs.HasErrors() {
+		return nil, errs
+	}
+
+	before, _ := s.repo.GetSpecifications(ctx, req.Id)
+
+	if err := s.repo.SetSpecifications(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resp := &entity.SpecificationsResponse{Id: req.Id, Specifications: req.Specifications}
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: req.Id,
+		Action:   auditEntity.ActionUpdate,
+		Before:   &entity.SpecificationsResponse{Id: req.Id, Specifications: before},
+		After:    resp,
+	})
+
+	return resp, nil
+}
+
+// SetBundleItems replaces the components of a bundle. The bundle stock
+// follows from the new components right away.
+func (s *productService) SetBundleItems(ctx context.Context, req *entity.SetBundleItemsRequest) (*entity.BundleItemsResponse, error) {
+	product, err := s.authorizeProduct(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.Type != entity.ProductTypeBundle {
+		return nil, errmsg.NewCustomErrors(422, errmsg.WithMessage("Produk bukan produk bundel"))
+	}
+
+	if err := s.checkBundleItems(ctx, product.ShopId, product.Id, "items", req.Items); err != nil {
+		return nil, err
+	}
+
+	before, _ := s.repo.GetBundleComponents(ctx, req.Id)
+
+	if err := s.repo.SetBundleItems(ctx, req); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetBundleComponents(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &entity.BundleItemsResponse{Id: req.Id, Stock: entity.BundleStock(items), Items: items}
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: req.Id,
+		Action:   auditEntity.ActionUpdate,
+		Before:   &entity.BundleItemsResponse{Id: req.Id, Stock: entity.BundleStock(before), Items: before},
+		After:    resp,
+	})
+
+	return resp, nil
+}
+
+// checkBundleItems makes sure every component is a live single product of
+// the shop, listed once, other than the bundle itself. field is the request
+// field of items in the errors.
+func (s *productService) checkBundleItems(ctx context.Context, shopId, bundleId, field string, items []entity.BundleItem) error {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProductId)
+	}
+
+	candidates, err := s.repo.GetBundleCandidates(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byId := make(map[string]entity.BundleCandidate, len(candidates))
+	for _, c := range candidates {
+		byId[c.Id] = c
+	}
+
+	var (
+		errs = errmsg.NewCustomErrors(400)
+		seen = make(map[string]bool, len(items))
+	)
+
+	for i, item := range items {
+		var (
+			key           = fmt.Sprintf("%s[%d].product_id", field, i)
+			candidate, ok = byId[item.ProductId]
+		)
+
+		switch {
+		case seen[item.ProductId]:
+			errs.Add(key, "produk sudah ada pada komponen lain.")
+		case item.ProductId == bundleId:
+			errs.Add(key, "bundel tidak dapat menjadi komponennya sendiri.")
+		case !ok:
+			errs.Add(key, "produk tidak ditemukan.")
+		case candidate.ShopId != shopId:
+			errs.Add(key, "produk harus berasal dari toko yang sama.")
+		case candidate.Type == entity.ProductTypeBundle:
+			errs.Add(key, "produk bundel tidak dapat menjadi komponen.")
+		}
+		seen[item.ProductId] = true
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	return nil
+}
+
+// specValue converts a decoded JSON value to the representation the
+// repository stores for typ: string, json.Number or bool. Numbers may also be
+// sent as strings.
+func specValue(typ string, value any) (any, bool) {
+	switch typ {
+	case entity.SpecTypeText:
+		v, ok := value.(string)
+		v = strings.TrimSpace(v)
+		return v, ok && v != "" && len(v) <= 255
+	case entity.SpecTypeNumber:
+		var n *big.Rat
+		switch v := value.(type) {
+		case float64:
+			n = new(big.Rat).SetFloat64(v)
+		case string:
+			n, _ = new(big.Rat).SetString(strings.TrimSpace(v))
+		}
+		// the column holds up to 14 integer digits
+		if n == nil || new(big.Rat).Abs(n).Cmp(big.NewRat(1e14, 1)) >= 0 {
+			return nil, false
+		}
+		return json.Number(entity.FormatSpecNumber(n)), true
+	case entity.SpecTypeBoolean:
+		v, ok := value.(bool)
+		return v, ok
+	}
+
+	return nil, false
+}
+
+// resolveTags normalizes the tag names and returns the ids to assign.
+// Unknown tags are created; banned tags are rejected.
+func (s *productService) resolveTags(ctx context.Context, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var (
+		errs       = errmsg.NewCustomErrors(400)
+		normalized = make([]string, 0, len(names))
+	)
+
+	for i, name := range names {
+		tag := entity.NormalizeTag(name)
+		if tag == "" {
+			errs.Add(fmt.Sprintf("tags[%d]", i), "tag harus mengandung huruf atau angka.")
+			continue
+		}
+		normalized = append(normalized, tag)
+	}
+
+	if errs.HasErrors() {
+		return nil, errs
+	}
+
+	tags, err := s.repo.ResolveTags(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag.Banned {
+			errs.Add("tags", fmt.Sprintf("tag %s tidak diizinkan.", tag.Name))
+			continue
+		}
+		ids = append(ids, tag.Id)
+	}
+
+	if errs.HasErrors() {
+		return nil, errs
+	}
+
+	return ids, nil
+}
+
+// getPriceTiers returns the tiers labeled with the shop currency.
+func (s *productService) getPriceTiers(ctx context.Context, id, currency string) ([]entity.PriceTier, error) {
+	tiers, err := s.repo.GetPriceTiers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tiers {
+		if tiers[i].Price, err = tiers[i].Price.WithCurrency(currency); err != nil {
+			return nil, err
+		}
+	}
+
+	return tiers, nil
+}
+
+func (s *productService) GetTranslations(ctx context.Context, req *entity.GetTranslationsRequest) (*entity.GetTranslationsResponse, error) {
+	if _, err := s.authorizeProduct(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetTranslations(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.GetTranslationsResponse{Id: req.Id, Items: items}, nil
+}
+
+// SetTranslation adds or replaces the translation of the product in
+// req.Locale.
+func (s *productService) SetTranslation(ctx context.Context, req *entity.SetTranslationRequest) (*entity.Translation, error) {
+	if _, err := s.authorizeProduct(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	req.Locale = types.NormalizeLocale(req.Locale)
+	before := s.findTranslation(ctx, req.Id, req.Locale)
+
+	var err error
+	req.DescriptionFormat, req.DescriptionHtml, req.DescriptionExcerpt, err = renderDescription(req.DescriptionFormat, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.SetTranslation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	action := auditEntity.ActionUpdate
+	if before == nil {
+		action = auditEntity.ActionCreate
+	}
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: req.Id,
+		Action:   action,
+		Before:   before,
+		After:    resp,
+	})
+
+	return resp, nil
+}
+
+func (s *productService) DeleteTranslation(ctx context.Context, req *entity.DeleteTranslationRequest) error {
+	if _, err := s.authorizeProduct(ctx, req.Id, req.UserId); err != nil {
+		return err
+	}
+
+	req.Locale = types.NormalizeLocale(req.Locale)
+	before := s.findTranslation(ctx, req.Id, req.Locale)
+
+	err := s.repo.DeleteTranslation(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Terjemahan tidak ditemukan"))
+	}
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityProduct,
+		EntityId: req.Id,
+		Action:   auditEntity.ActionDelete,
+		Before:   before,
+	})
+
+	return nil
+}
+
+// findTranslation returns the current translation for the audit log, nil
+// when there is none.
+func (s *productService) findTranslation(ctx context.Context, id, locale string) *entity.Translation {
+	items, err := s.repo.GetTranslations(ctx, id)
+	if err != nil {
+		return nil
+	}
+
+	for i := range items {
+		if items[i].Locale == locale {
+			return &items[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *productService) SetCategoryTranslation(ctx context.Context, req *entity.SetCategoryTranslationRequest) error {
+	req.Locale = types.NormalizeLocale(req.Locale)
+
+	err := s.repo.SetCategoryTranslation(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Kategori tidak ditemukan"))
+	}
+
+	return err
+}
+
+func (s *productService) DeleteCategoryTranslation(ctx context.Context, req *entity.DeleteCategoryTranslationRequest) error {
+	req.Locale = types.NormalizeLocale(req.Locale)
+
+	err := s.repo.DeleteCategoryTranslation(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Terjemahan tidak ditemukan"))
+	}
+
+	return err
+}
+
+// renderDescription returns the format, defaulting to plain, the sanitized
+// HTML and the listing excerpt of a description.
+func renderDescription(format, src string) (string, string, string, error) {
+	if format == "" {
+		format = richtext.FormatPlain
+	}
+
+	html, err := richtext.Render(format, src)
+	if err != nil {
+		return "", "", "", errmsg.NewCustomErrors(400, errmsg.WithErrors("description_format", "format deskripsi tidak didukung."))
+	}
+
+	return format, html, richtext.Excerpt(html, entity.DescriptionExcerptLength), nil
+}
+
+func (s *productService) rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	rate, err := s.rates.Rate(ctx, from, to)
+	if errors.Is(err, exchangeRateEntity.ErrRateNotFound) {
+		return nil, errmsg.NewCustomErrors(422, errmsg.WithMessage(fmt.Sprintf("Kurs %s ke %s belum tersedia", from, to)), errmsg.WithErrors("currency", "mata uang tidak didukung."))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+// checkCurrency rejects a price that is not in the shop currency.
+func checkCurrency(currency, field string, price types.Money) error {
+	if price.Currency != currency {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors(field, entity.CurrencyMessage(currency)))
+	}
+
+	return nil
+}
+
+// authorizeShop returns the shop when it exists and is owned by userId.
+func (s *productService) authorizeShop(ctx context.Context, shopId, userId string) (*entity.ShopOwnership, error) {
+	shop, err := s.repo.GetShopOwnership(ctx, shopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if shop.UserId != userId {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke toko ini"))
+	}
+
+	return shop, nil
+}
+
+// authorizeView returns the product when anyone may see it. A product that
+// is not active is only shown to the shop owner; everyone else gets the same
+// 404 as for a missing product.
+func (s *productService) authorizeView(ctx context.Context, id, userId string) (*entity.ProductOwnership, error) {
+	product, err := s.repo.GetProductOwnership(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if product.Status != entity.ProductStatusActive && (userId == "" || product.UserId != userId) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+
+	return product, nil
+}
+
+// authorizeProduct returns the product when it exists and belongs to a shop
+// owned by userId.
+func (s *productService) authorizeProduct(ctx context.Context, id, userId string) (*entity.ProductOwnership, error) {
+	product, err := s.repo.GetProductOwnership(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if product.UserId != userId {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke produk ini"))
+	}
+
+	return product, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"codebase-app/internal/module/product/entity"
+	"codebase-app/internal/module/product/ports"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/types"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ownerId    = "8d6e1d55-3a8c-4a57-b7a4-2f0c7a0e6f01"
+	strangerId = "0b5f4c9e-6a4d-4c1e-9a61-0d3b7e2a9c02"
+)
+
+type fakeProductRepository struct {
+	ports.ProductRepository
+	products map[string]*entity.GetProductDetailResponse
+}
+
+func (f *fakeProductRepository) GetProductOwnership(ctx context.Context, id string) (*entity.ProductOwnership, error) {
+	p, ok := f.products[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &entity.ProductOwnership{Id: p.Id, ShopId: p.Shop.Id, UserId: ownerId, Type: p.Type, Price: p.Price, Currency: p.Currency, Status: p.Status}, nil
+}
+
+func (f *fakeProductRepository) GetDetailProduct(ctx context.Context, req *entity.GetProductDetailRequest) (*entity.GetProductDetailResponse, error) {
+	p, ok := f.products[req.Id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	resp := *p
+	return &resp, nil
+}
+
+func (f *fakeProductRepository) GetPriceTiers(ctx context.Context, id string) ([]entity.PriceTier, error) {
+	return []entity.PriceTier{}, nil
+}
+
+func (f *fakeProductRepository) GetSpecifications(ctx context.Context, id string) ([]entity.Specification, error) {
+	return []entity.Specification{}, nil
+}
+
+func (f *fakeProductRepository) GetPriceHistory(ctx context.Context, req *entity.GetPriceHistoryRequest) (*entity.GetPriceHistoryResponse, error) {
+	return &entity.GetPriceHistoryResponse{Items: []entity.PriceHistoryItem{}}, nil
+}
+
+func TestNonActiveProductIsHidden(t *testing.T) {
+	var (
+		price = types.NewMoney(1000000, "IDR")
+		repo  = &fakeProductRepository{products: map[string]*entity.GetProductDetailResponse{}}
+		s     = NewProductService(repo, nil, nil, nil, NewPricingService(), nil, nil)
+		ctx   = context.Background()
+	)
+
+	statuses := map[string]string{
+		entity.ProductStatusActive:   "5e0b0c2e-1f7a-4d1b-8c55-3a9d2f6e7b01",
+		entity.ProductStatusFlagged:  "5e0b0c2e-1f7a-4d1b-8c55-3a9d2f6e7b02",
+		entity.ProductStatusRejected: "5e0b0c2e-1f7a-4d1b-8c55-3a9d2f6e7b03",
+		entity.ProductStatusInactive: "5e0b0c2e-1f7a-4d1b-8c55-3a9d2f6e7b04",
+	}
+	for status, id := range statuses {
+		repo.products[id] = &entity.GetProductDetailResponse{
+			Id:             id,
+			Type:           entity.ProductTypeSingle,
+			Price:          price,
+			EffectivePrice: price,
+			Currency:       "IDR",
+			Status:         status,
+		}
+	}
+
+	for status, id := range statuses {
+		for caller, userId := range map[string]string{"anonymous": "", "stranger": strangerId, "owner": ownerId} {
+			visible := status == entity.ProductStatusActive || caller == "owner"
+
+			t.Run(status+" product to "+caller, func(t *testing.T) {
+				calls := map[string]func() error{
+					"detail": func() error {
+						_, err := s.GetDetailProduct(ctx, &entity.GetProductDetailRequest{UserId: userId, Id: id})
+						return err
+					},
+					"price quote": func() error {
+						_, err := s.GetPriceQuote(ctx, &entity.GetPriceQuoteRequest{UserId: userId, Id: id, Quantity: 1})
+						return err
+					},
+					"price history": func() error {
+						_, err := s.GetPriceHistory(ctx, &entity.GetPriceHistoryRequest{UserId: userId, Id: id, Page: 1, Paginate: 10})
+						return err
+					},
+				}
+
+				for name, call := range calls {
+					err := call()
+					if visible {
+						assert.NoError(t, err, name)
+						continue
+					}
+
+					var customErr *errmsg.CustomError
+					if assert.ErrorAs(t, err, &customErr, name) {
+						assert.Equal(t, 404, customErr.Code, name)
+						assert.Equal(t, "Produk tidak ditemukan", customErr.Msg, name)
+					}
+				}
+			})
+		}
+	}
+}
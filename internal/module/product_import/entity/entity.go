@@ -0,0 +1,100 @@
+package entity
+
+import (
+	productEntity "codebase-app/internal/module/product/entity"
+	"codebase-app/pkg/types"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusCompleted  = "completed"
+	StatusFailed     = "failed"
+)
+
+type CreateImportJobRequest struct {
+	UserId string `prop:"user_id" validate:"uuid" db:"user_id"`
+
+	ShopId   string `form:"shop_id" validate:"required,uuid" db:"shop_id"`
+	Filename string `validate:"required" db:"filename"`
+	Format   string `validate:"required,oneof=csv json" db:"format"`
+	Content  []byte `validate:"required"`
+}
+
+type CreateImportJobResponse struct {
+	Id        string `json:"id" db:"id"`
+	TotalRows int    `json:"total_rows" db:"total_rows"`
+}
+
+// ImportRow is a single product parsed from the uploaded file. The
+// validation rules mirror product.CreateProductRequest.
+type ImportRow struct {
+	Row    int    `json:"-"`
+	ShopId string `json:"-" db:"shop_id"`
+
+	Name              string      `json:"name" validate:"required,min=3,max=100" db:"name"`
+	Brand             string      `json:"brand" validate:"required,min=3" db:"brand"`
+	Price             types.Money `json:"price" validate:"required,gt=0" db:"price"`
+	Stock             int         `json:"stock" validate:"required,min=1" db:"stock"`
+	CategoryId        string      `json:"category_id" validate:"required,uuid" db:"category_id"`
+	Description       string      `json:"description" validate:"max=20000" db:"description"`
+	DescriptionFormat string      `json:"description_format" validate:"omitempty,oneof=plain markdown html" db:"description_format"`
+	ImageUrl          string      `json:"image_url" db:"image_url"`
+
+	// DescriptionHtml and DescriptionExcerpt are rendered once the row is
+	// valid.
+	DescriptionHtml    string `json:"-" db:"description_html"`
+	DescriptionExcerpt string `json:"-" db:"description_excerpt"`
+	// Slug is made from Name once the row is valid, see pkg.Slugify.
+	Slug string `json:"-" db:"slug"`
+	// Status is flagged when the row has banned keywords, the
+	// ModerationReasons, and active otherwise.
+	Status            string   `json:"-" db:"status"`
+	ModerationReasons []string `json:"-" db:"-"`
+	// Duplicate is the near duplicate the row matched under
+	// DuplicatePolicyFlag, flagged once the row is inserted.
+	Duplicate *productEntity.DuplicateMatch `json:"-" db:"-"`
+}
+
+type ImportRowError struct {
+	Row    int                 `json:"row" db:"row_number"`
+	Errors map[string][]string `json:"errors"`
+}
+
+type UpdateImportJobRequest struct {
+	Id            string `db:"id"`
+	Status        string `db:"status"`
+	ProcessedRows int    `db:"processed_rows"`
+	SuccessRows   int    `db:"success_rows"`
+	FailedRows    int    `db:"failed_rows"`
+	ErrorMessage  string `db:"error_message"`
+}
+
+type GetImportJobRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+}
+
+type GetImportJobResponse struct {
+	Id            string     `json:"id" db:"id"`
+	ShopId        string     `json:"shop_id" db:"shop_id"`
+	Filename      string     `json:"filename" db:"filename"`
+	Format        string     `json:"format" db:"format"`
+	Status        string     `json:"status" db:"status"`
+	TotalRows     int        `json:"total_rows" db:"total_rows"`
+	ProcessedRows int        `json:"processed_rows" db:"processed_rows"`
+	SuccessRows   int        `json:"success_rows" db:"success_rows"`
+	FailedRows    int        `json:"failed_rows" db:"failed_rows"`
+	ErrorMessage  string     `json:"error_message" db:"error_message"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	FinishedAt    *time.Time `json:"finished_at" db:"finished_at"`
+}
+
+type GetImportErrorsResponse struct {
+	Items []ImportRowError `json:"items"`
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"bytes"
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/middleware"
+	auditRepository "codebase-app/internal/module/audit/repository"
+	auditService "codebase-app/internal/module/audit/service"
+	moderationRepository "codebase-app/internal/module/moderation/repository"
+	moderationService "codebase-app/internal/module/moderation/service"
+	notificationRepository "codebase-app/internal/module/notification/repository"
+	notificationService "codebase-app/internal/module/notification/service"
+	productEntity "codebase-app/internal/module/product/entity"
+	productRepository "codebase-app/internal/module/product/repository"
+	productService "codebase-app/internal/module/product/service"
+	"codebase-app/internal/module/product_import/entity"
+	"codebase-app/internal/module/product_import/ports"
+	"codebase-app/internal/module/product_import/repository"
+	"codebase-app/internal/module/product_import/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+	"encoding/csv"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type productImportHandler struct {
+	service ports.ProductImportService
+}
+
+func NewProductImportHandler() *productImportHandler {
+	var (
+		handler      = new(productImportHandler)
+		repo         = repository.NewProductImportRepository(adapter.Adapters.ShopeefunPostgres)
+		audit        = auditService.NewAuditService(auditRepository.NewAuditRepository(adapter.Adapters.ShopeefunPostgres))
+		notification = notificationService.NewNotificationService(notificationRepository.NewNotificationRepository(adapter.Adapters.ShopeefunPostgres))
+		moderator    = moderationService.NewModerationService(moderationRepository.NewModerationRepository(adapter.Adapters.ShopeefunPostgres), notification)
+		duplicates   = productService.NewDuplicateDetector(productRepository.NewProductRepository(adapter.Adapters.ShopeefunPostgres), productEntity.DuplicatePolicy(config.Envs.Product.DuplicatePolicy))
+		service      = service.NewProductImportService(repo, adapter.Adapters.Validator, audit, moderator, duplicates, adapter.Adapters.Workers)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *productImportHandler) Register(router fiber.Router) {
+	router.Post("/product/import", middleware.UserIdHeader, h.CreateImportJob)
+	router.Get("/product/import/:id", middleware.UserIdHeader, h.GetImportJob)
+	router.Get("/product/import/:id/errors", middleware.UserIdHeader, h.GetImportErrors)
+}
+
+func (h *productImportHandler) CreateImportJob(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateImportJobRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateImportJob - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::CreateImportJob - Get form file")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(errmsg.NewCustomErrors(fiber.StatusBadRequest, errmsg.WithErrors("file", "file harus diisi."))))
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		log.Error().Err(err).Msg("handler::CreateImportJob - Open form file")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+	defer f.Close()
+
+	req.Content, err = io.ReadAll(f)
+	if err != nil {
+		log.Error().Err(err).Msg("handler::CreateImportJob - Read form file")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Filename = file.Filename
+	req.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Str("filename", req.Filename).Msg("handler::CreateImportJob - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateImportJob(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response.Success(resp, "Import produk sedang diproses"))
+}
+
+func (h *productImportHandler) GetImportJob(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetImportJobRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetImportJob - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetImportJob(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+// GetImportErrors returns the per-row error report. By default it is sent
+// as a CSV attachment; ?format=json returns it in the usual envelope.
+func (h *productImportHandler) GetImportErrors(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetImportJobRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetImportErrors - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetImportErrors(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if c.Query("format") == entity.FormatJSON {
+		return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+	}
+
+	var (
+		buf = new(bytes.Buffer)
+		w   = csv.NewWriter(buf)
+	)
+
+	_ = w.Write([]string{"row", "field", "message"})
+	for _, item := range resp.Items {
+		fields := make([]string, 0, len(item.Errors))
+		for field := range item.Errors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			for _, msg := range item.Errors[field] {
+				_ = w.Write([]string{strconv.Itoa(item.Row), field, msg})
+			}
+		}
+	}
+	w.Flush()
+
+	c.Attachment("import-" + req.Id + "-errors.csv")
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
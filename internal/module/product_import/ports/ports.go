@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"codebase-app/internal/module/product_import/entity"
+	"context"
+)
+
+type ProductImportRepository interface {
+	GetShopCurrency(ctx context.Context, shopId, userId string) (string, error)
+	CreateImportJob(ctx context.Context, req *entity.CreateImportJobRequest, totalRows int) (*entity.CreateImportJobResponse, error)
+	UpdateImportJob(ctx context.Context, req *entity.UpdateImportJobRequest) error
+	CreateProducts(ctx context.Context, rows []entity.ImportRow) ([]string, error)
+	CreateImportErrors(ctx context.Context, jobId string, errs []entity.ImportRowError) error
+	GetImportJob(ctx context.Context, req *entity.GetImportJobRequest) (*entity.GetImportJobResponse, error)
+	GetImportErrors(ctx context.Context, req *entity.GetImportJobRequest) (*entity.GetImportErrorsResponse, error)
+}
+
+type ProductImportService interface {
+	CreateImportJob(ctx context.Context, req *entity.CreateImportJobRequest) (*entity.CreateImportJobResponse, error)
+	GetImportJob(ctx context.Context, req *entity.GetImportJobRequest) (*entity.GetImportJobResponse, error)
+	GetImportErrors(ctx context.Context, req *entity.GetImportJobRequest) (*entity.GetImportErrorsResponse, error)
+}
@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"codebase-app/internal/module/product_import/entity"
+	"codebase-app/internal/module/product_import/ports"
+	"context"
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.ProductImportRepository = &productImportRepository{}
+
+type productImportRepository struct {
+	db *sqlx.DB
+}
+
+func NewProductImportRepository(db *sqlx.DB) *productImportRepository {
+	return &productImportRepository{
+		db: db,
+	}
+}
+
+// GetShopCurrency returns the currency of the shop, or sql.ErrNoRows when the
+// shop does not exist or belongs to another user.
+func (r *productImportRepository) GetShopCurrency(ctx context.Context, shopId, userId string) (string, error) {
+	var currency string
+
+	query := `SELECT currency FROM shops WHERE id = ? AND user_id = ? AND deleted_at IS NULL`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), shopId, userId).Scan(&currency)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Str("user_id", userId).Msg("repository::GetShopCurrency - Failed to get shop currency")
+		return "", err
+	}
+
+	return currency, nil
+}
+
+func (r *productImportRepository) CreateImportJob(ctx context.Context, req *entity.CreateImportJobRequest, totalRows int) (*entity.CreateImportJobResponse, error) {
+	var resp = new(entity.CreateImportJobResponse)
+
+	query := `
+		INSERT INTO product_import_jobs (user_id, shop_id, filename, format, total_rows)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id, total_rows
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.UserId,
+		req.ShopId,
+		req.Filename,
+		req.Format,
+		totalRows).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", req.ShopId).Str("filename", req.Filename).Msg("repository::CreateImportJob - Failed to create import job")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productImportRepository) UpdateImportJob(ctx context.Context, req *entity.UpdateImportJobRequest) error {
+	query := `
+		UPDATE product_import_jobs
+		SET
+			status = ?,
+			processed_rows = ?,
+			success_rows = ?,
+			failed_rows = ?,
+			error_message = NULLIF(?, ''),
+			updated_at = NOW(),
+			finished_at = CASE WHEN ? IN ('completed', 'failed') THEN NOW() ELSE NULL END
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		req.Status,
+		req.ProcessedRows,
+		req.SuccessRows,
+		req.FailedRows,
+		req.ErrorMessage,
+		req.Status,
+		req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateImportJob - Failed to update import job")
+		return err
+	}
+
+	return nil
+}
+
+// CreateProducts inserts all rows in a single statement. Either every row is
+// inserted or none is, so callers can fall back to smaller batches.
+func (r *productImportRepository) CreateProducts(ctx context.Context, rows []entity.ImportRow) ([]string, error) {
+	type dao struct {
+		entity.ImportRow
+		ModerationReasons pq.StringArray `db:"moderation_reasons"`
+	}
+
+	var (
+		ids  = make([]string, 0, len(rows))
+		data = make([]dao, 0, len(rows))
+	)
+
+	for _, row := range rows {
+		data = append(data, dao{ImportRow: row, ModerationReasons: row.ModerationReasons})
+	}
+
+	// The importing user owns the shop, so the price history actor is taken
+	// from shops.user_id. Each slug is the first free one of :slug, :slug-2,
+	// ... among the live products of the shop and their previous slugs, as
+	// for products created one at a time. Rows of the same batch don't see
+	// each other's slug, so a duplicate name fails the batch. Flagged rows
+	// wait in the moderation queue and become active once approved.
+	query := `
+		WITH created AS (
+			INSERT INTO product (name, brand, price, stock, category_id, shop_id, slug, description, description_format, description_html, description_excerpt, image_url,
+				status, moderation_reasons, moderation_previous_status, moderation_flagged_at)
+			VALUES (
+				:name, :brand, :price, :stock, :category_id, :shop_id,
+				(
+					SELECT c.slug
+					FROM (SELECT COALESCE(NULLIF(CAST(:slug AS TEXT), ''), 'produk') as base) b
+					CROSS JOIN generate_series(1, 10000) n
+					CROSS JOIN LATERAL (SELECT CASE WHEN n = 1 THEN b.base ELSE b.base || '-' || n END as slug) c
+					WHERE
+						NOT EXISTS (
+							SELECT 1 FROM product o
+							WHERE o.shop_id = CAST(:shop_id AS UUID) AND o.slug = c.slug AND o.deleted_at IS NULL
+						)
+						AND NOT EXISTS (
+							SELECT 1
+							FROM product_slug_redirects rd
+							JOIN product rp ON rp.id = rd.product_id AND rp.deleted_at IS NULL
+							WHERE rd.shop_id = CAST(:shop_id AS UUID) AND rd.slug = c.slug
+						)
+					ORDER BY n
+					LIMIT 1
+				),
+				NULLIF(:description, ''), :description_format, NULLIF(:description_html, ''), NULLIF(:description_excerpt, ''),
+				NULLIF(:image_url, ''),
+				:status,
+				COALESCE(CAST(:moderation_reasons AS TEXT[]), '{}'),
+				CASE WHEN CAST(:status AS VARCHAR) = 'flagged' THEN 'active' END,
+				CASE WHEN CAST(:status AS VARCHAR) = 'flagged' THEN NOW() END
+			)
+			RETURNING id, price, shop_id
+		), history AS (
+			INSERT INTO product_price_history (product_id, price, changed_by)
+			SELECT c.id, c.price, s.user_id
+			FROM created c
+			JOIN shops s ON s.id = c.shop_id
+		)
+		SELECT id FROM created
+	`
+
+	stmt, args, err := sqlx.Named(query, data)
+	if err != nil {
+		log.Error().Err(err).Int("rows", len(rows)).Msg("repository::CreateProducts - Failed to bind rows")
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &ids, r.db.Rebind(stmt), args...)
+	if err != nil {
+		log.Warn().Err(err).Int("rows", len(rows)).Msg("repository::CreateProducts - Failed to insert products")
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *productImportRepository) CreateImportErrors(ctx context.Context, jobId string, errs []entity.ImportRowError) error {
+	type dao struct {
+		JobId  string `db:"job_id"`
+		Row    int    `db:"row_number"`
+		Errors string `db:"errors"`
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	data := make([]dao, 0, len(errs))
+	for _, e := range errs {
+		b, err := json.Marshal(e.Errors)
+		if err != nil {
+			log.Error().Err(err).Str("job_id", jobId).Msg("repository::CreateImportErrors - Failed to marshal row errors")
+			return err
+		}
+		data = append(data, dao{JobId: jobId, Row: e.Row, Errors: string(b)})
+	}
+
+	query := `
+		INSERT INTO product_import_errors (job_id, row_number, errors)
+		VALUES (:job_id, :row_number, CAST(:errors AS JSONB))
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, data)
+	if err != nil {
+		log.Error().Err(err).Str("job_id", jobId).Msg("repository::CreateImportErrors - Failed to create import errors")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productImportRepository) GetImportJob(ctx context.Context, req *entity.GetImportJobRequest) (*entity.GetImportJobResponse, error) {
+	var resp = new(entity.GetImportJobResponse)
+
+	query := `
+		SELECT
+			id,
+			shop_id,
+			filename,
+			format,
+			status,
+			total_rows,
+			processed_rows,
+			success_rows,
+			failed_rows,
+			COALESCE(error_message, '') as error_message,
+			created_at,
+			finished_at
+		FROM product_import_jobs
+		WHERE id = ? AND user_id = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id, req.UserId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetImportJob - Failed to get import job")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productImportRepository) GetImportErrors(ctx context.Context, req *entity.GetImportJobRequest) (*entity.GetImportErrorsResponse, error) {
+	type dao struct {
+		Row    int    `db:"row_number"`
+		Errors []byte `db:"errors"`
+	}
+
+	var (
+		resp = new(entity.GetImportErrorsResponse)
+		data = make([]dao, 0)
+	)
+	resp.Items = make([]entity.ImportRowError, 0)
+
+	query := `
+		SELECT e.row_number, e.errors
+		FROM product_import_errors e
+		JOIN product_import_jobs j ON j.id = e.job_id
+		WHERE e.job_id = ? AND j.user_id = ?
+		ORDER BY e.row_number
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), req.Id, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetImportErrors - Failed to get import errors")
+		return nil, err
+	}
+
+	for _, d := range data {
+		item := entity.ImportRowError{Row: d.Row}
+		if err := json.Unmarshal(d.Errors, &item.Errors); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::GetImportErrors - Failed to unmarshal row errors")
+			return nil, err
+		}
+		resp.Items = append(resp.Items, item)
+	}
+
+	return resp, nil
+}
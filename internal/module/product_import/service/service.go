@@ -0,0 +1,448 @@
+package service
+
+import (
+	"bytes"
+	"codebase-app/internal/adapter"
+	auditEntity "codebase-app/internal/module/audit/entity"
+	auditPorts "codebase-app/internal/module/audit/ports"
+	productEntity "codebase-app/internal/module/product/entity"
+	productPorts "codebase-app/internal/module/product/ports"
+	"codebase-app/internal/module/product_import/entity"
+	"codebase-app/internal/module/product_import/ports"
+	"codebase-app/pkg"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/richtext"
+	"codebase-app/pkg/types"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.ProductImportService = &productImportService{}
+
+const (
+	// batchSize is the number of valid rows inserted per statement.
+	batchSize = 500
+	// maxRows caps a single upload so one job cannot hog the database.
+	maxRows = 10000
+)
+
+// requiredColumns must be present in the CSV header, description,
+// description_format and image_url are optional.
+var requiredColumns = []string{"name", "brand", "price", "stock", "category_id"}
+
+type productImportService struct {
+	repo       ports.ProductImportRepository
+	validator  adapter.Validator
+	audit      auditPorts.AuditService
+	moderator  productPorts.ProductModerator
+	duplicates productPorts.DuplicateDetector
+	workers    *adapter.Workers
+}
+
+func NewProductImportService(repo ports.ProductImportRepository, v adapter.Validator, audit auditPorts.AuditService, moderator productPorts.ProductModerator, duplicates productPorts.DuplicateDetector, workers *adapter.Workers) *productImportService {
+	return &productImportService{
+		repo:       repo,
+		validator:  v,
+		audit:      audit,
+		moderator:  moderator,
+		duplicates: duplicates,
+		workers:    workers,
+	}
+}
+
+// CreateImportJob parses the uploaded file, registers a job and processes the
+// rows in the background. Parse failures of the file as a whole are returned
+// immediately; per-row problems end up in the job's error report.
+func (s *productImportService) CreateImportJob(ctx context.Context, req *entity.CreateImportJobRequest) (*entity.CreateImportJobResponse, error) {
+	currency, err := s.repo.GetShopCurrency(ctx, req.ShopId, req.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke toko ini"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, rowErrs, err := parseRows(req.Format, req.Content, currency)
+	if err != nil {
+		log.Warn().Err(err).Str("filename", req.Filename).Msg("service::CreateImportJob - Failed to parse file")
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithMessage("File tidak dapat dibaca"), errmsg.WithErrors("file", err.Error()))
+	}
+
+	total := len(rows) + len(rowErrs)
+	if total == 0 {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("file", "file tidak berisi data produk."))
+	}
+	if total > maxRows {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("file", fmt.Sprintf("file harus tidak lebih dari %d baris.", maxRows)))
+	}
+
+	resp, err := s.repo.CreateImportJob(ctx, req, total)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].ShopId = req.ShopId
+	}
+
+	// the request context is gone once the handler returns, the job runs on
+	// the workers' context which is cancelled on shutdown
+	queued := s.workers.Submit(func(ctx context.Context) {
+		s.process(ctx, resp.Id, req.UserId, rows, rowErrs)
+	})
+	if !queued {
+		log.Warn().Str("job_id", resp.Id).Msg("service::CreateImportJob - Workers are busy")
+		_ = s.repo.UpdateImportJob(ctx, &entity.UpdateImportJobRequest{
+			Id:           resp.Id,
+			Status:       entity.StatusFailed,
+			ErrorMessage: "server sedang sibuk, silakan unggah ulang file",
+		})
+		return nil, errmsg.NewCustomErrors(503, errmsg.WithMessage("Server sedang sibuk, silakan coba lagi nanti"))
+	}
+
+	return resp, nil
+}
+
+func (s *productImportService) GetImportJob(ctx context.Context, req *entity.GetImportJobRequest) (*entity.GetImportJobResponse, error) {
+	return s.repo.GetImportJob(ctx, req)
+}
+
+func (s *productImportService) GetImportErrors(ctx context.Context, req *entity.GetImportJobRequest) (*entity.GetImportErrorsResponse, error) {
+	if _, err := s.repo.GetImportJob(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetImportErrors(ctx, req)
+}
+
+func (s *productImportService) process(ctx context.Context, jobId, userId string, rows []entity.ImportRow, rowErrs []entity.ImportRowError) {
+	var (
+		progress = &entity.UpdateImportJobRequest{Id: jobId, Status: entity.StatusProcessing}
+		valid    = make([]entity.ImportRow, 0, batchSize)
+		log      = log.With().Str("job_id", jobId).Logger()
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Any("panic", r).Msg("service::process - Import job panicked")
+			progress.Status = entity.StatusFailed
+			progress.ErrorMessage = "terjadi kesalahan saat memproses file"
+			_ = s.repo.UpdateImportJob(context.WithoutCancel(ctx), progress)
+		}
+	}()
+
+	// stopped marks the job failed once the server shuts down, the rows
+	// inserted so far are kept
+	stopped := func() bool {
+		if ctx.Err() == nil {
+			return false
+		}
+		log.Warn().Any("progress", progress).Msg("service::process - Import job stopped")
+		progress.Status = entity.StatusFailed
+		progress.ErrorMessage = "impor dihentikan karena server dimatikan, silakan unggah ulang baris yang belum diproses"
+		_ = s.repo.UpdateImportJob(context.WithoutCancel(ctx), progress)
+		return true
+	}
+
+	if stopped() {
+		return
+	}
+
+	progress.ProcessedRows = len(rowErrs)
+	progress.FailedRows = len(rowErrs)
+	if err := s.repo.UpdateImportJob(ctx, progress); err != nil {
+		return
+	}
+
+	flush := func() {
+		if len(valid) == 0 {
+			return
+		}
+
+		failed := s.insert(ctx, userId, valid)
+		if err := s.repo.CreateImportErrors(ctx, jobId, failed); err != nil {
+			log.Error().Err(err).Msg("service::process - Failed to store row errors")
+		}
+
+		progress.ProcessedRows += len(valid)
+		progress.SuccessRows += len(valid) - len(failed)
+		progress.FailedRows += len(failed)
+		_ = s.repo.UpdateImportJob(ctx, progress)
+
+		valid = valid[:0]
+	}
+
+	if err := s.repo.CreateImportErrors(ctx, jobId, rowErrs); err != nil {
+		log.Error().Err(err).Msg("service::process - Failed to store row errors")
+	}
+
+	for _, row := range rows {
+		if stopped() {
+			return
+		}
+
+		if err := s.validator.Validate(&row); err != nil {
+			_, errs := errmsg.Errors(err, &row)
+			fieldErrs, _ := errs.(map[string][]string)
+			if err := s.repo.CreateImportErrors(ctx, jobId, []entity.ImportRowError{{Row: row.Row, Errors: fieldErrs}}); err != nil {
+				log.Error().Err(err).Msg("service::process - Failed to store row errors")
+			}
+			progress.ProcessedRows++
+			progress.FailedRows++
+			continue
+		}
+
+		if row.DescriptionFormat == "" {
+			row.DescriptionFormat = richtext.FormatPlain
+		}
+		// the format is valid here, so rendering cannot fail
+		row.DescriptionHtml, _ = richtext.Render(row.DescriptionFormat, row.Description)
+		row.DescriptionExcerpt = richtext.Excerpt(row.DescriptionHtml, productEntity.DescriptionExcerptLength)
+		row.Slug = pkg.Slugify(row.Name)
+
+		reasons, err := s.moderator.Screen(ctx, &productEntity.ModerationContent{
+			CategoryId:  row.CategoryId,
+			Name:        row.Name,
+			Description: row.Description,
+		})
+		if err != nil {
+			if err := s.repo.CreateImportErrors(ctx, jobId, []entity.ImportRowError{{Row: row.Row, Errors: map[string][]string{
+				"row": {"produk gagal diperiksa."},
+			}}}); err != nil {
+				log.Error().Err(err).Msg("service::process - Failed to store row errors")
+			}
+			progress.ProcessedRows++
+			progress.FailedRows++
+			continue
+		}
+		row.ModerationReasons = reasons
+		row.Status = productEntity.ProductStatusActive
+		if len(reasons) > 0 {
+			row.Status = productEntity.ProductStatusFlagged
+		}
+
+		// rows are checked against the products already saved, which
+		// includes the earlier batches of this file but not the rows
+		// waiting in the current one
+		row.Duplicate, err = s.duplicates.Check(ctx, &productEntity.DuplicateCheck{
+			ShopId:     row.ShopId,
+			Name:       row.Name,
+			Brand:      row.Brand,
+			CategoryId: row.CategoryId,
+			Price:      row.Price,
+		})
+		if err != nil {
+			_, errs := errmsg.Errors[error](err)
+			fieldErrs, _ := errs.(map[string][]string)
+			if len(fieldErrs) == 0 {
+				fieldErrs = map[string][]string{"row": {"produk gagal diperiksa."}}
+			}
+			if err := s.repo.CreateImportErrors(ctx, jobId, []entity.ImportRowError{{Row: row.Row, Errors: fieldErrs}}); err != nil {
+				log.Error().Err(err).Msg("service::process - Failed to store row errors")
+			}
+			progress.ProcessedRows++
+			progress.FailedRows++
+			continue
+		}
+
+		valid = append(valid, row)
+		if len(valid) == batchSize {
+			flush()
+		}
+	}
+	flush()
+	if stopped() {
+		return
+	}
+
+	progress.Status = entity.StatusCompleted
+	if err := s.repo.UpdateImportJob(ctx, progress); err != nil {
+		return
+	}
+
+	log.Info().Any("progress", progress).Msg("service::process - Import job completed")
+}
+
+// insert writes a batch of valid rows. When the batch statement fails (for
+// instance an unknown category, or two rows of the same name taking the same
+// slug) the rows are retried one by one so only the offending rows are
+// reported.
+func (s *productImportService) insert(ctx context.Context, userId string, rows []entity.ImportRow) []entity.ImportRowError {
+	ids, err := s.repo.CreateProducts(ctx, rows)
+	if err == nil {
+		s.created(ctx, userId, ids, rows)
+		return nil
+	}
+
+	failed := make([]entity.ImportRowError, 0)
+	for i := range rows {
+		ids, err := s.repo.CreateProducts(ctx, rows[i:i+1])
+		if err != nil {
+			_, errs := errmsg.Errors[error](err)
+			fieldErrs, _ := errs.(map[string][]string)
+			if len(fieldErrs) == 0 {
+				fieldErrs = map[string][]string{"row": {"produk gagal disimpan."}}
+			}
+			failed = append(failed, entity.ImportRowError{Row: rows[i].Row, Errors: fieldErrs})
+			continue
+		}
+		s.created(ctx, userId, ids, rows[i:i+1])
+	}
+
+	return failed
+}
+
+// created flags the near duplicates among the inserted rows and records
+// them in the audit log.
+func (s *productImportService) created(ctx context.Context, userId string, ids []string, rows []entity.ImportRow) {
+	for i, id := range ids {
+		if rows[i].Duplicate != nil {
+			s.duplicates.Flag(ctx, id, rows[i].Duplicate)
+		}
+
+		s.audit.Record(ctx, &auditEntity.RecordRequest{
+			ActorId:  userId,
+			Entity:   auditEntity.EntityProduct,
+			EntityId: id,
+			Action:   auditEntity.ActionCreate,
+			After:    rows[i],
+		})
+	}
+}
+
+// parseRows turns the uploaded file into rows. Row numbers are 1-based and
+// count data rows only (the CSV header is not a row). Cells that cannot be
+// converted to the target type are reported as row errors. Prices are in the
+// shop's currency: CSV cells and legacy JSON numbers are read in it and JSON
+// prices in another currency are rejected.
+func parseRows(format string, content []byte, currency string) ([]entity.ImportRow, []entity.ImportRowError, error) {
+	switch format {
+	case entity.FormatCSV:
+		return parseCSV(content, currency)
+	case entity.FormatJSON:
+		return parseJSON(content, currency)
+	}
+
+	return nil, nil, fmt.Errorf("format %q tidak didukung", format)
+}
+
+func parseJSON(content []byte, currency string) ([]entity.ImportRow, []entity.ImportRowError, error) {
+	var (
+		raws    []json.RawMessage
+		rows    = make([]entity.ImportRow, 0)
+		rowErrs = make([]entity.ImportRowError, 0)
+	)
+
+	if err := json.Unmarshal(content, &raws); err != nil {
+		return nil, nil, errors.New("file JSON harus berisi array produk")
+	}
+
+	for i, raw := range raws {
+		row := entity.ImportRow{Row: i + 1, Price: types.NewMoney(0, currency)}
+		if err := json.Unmarshal(raw, &row); err != nil {
+			field := "row"
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				field = typeErr.Field
+			}
+			rowErrs = append(rowErrs, entity.ImportRowError{
+				Row:    i + 1,
+				Errors: map[string][]string{field: {"format " + strings.ReplaceAll(field, "_", " ") + " tidak valid."}},
+			})
+			continue
+		}
+		if row.Price.Currency != currency {
+			rowErrs = append(rowErrs, entity.ImportRowError{
+				Row:    i + 1,
+				Errors: map[string][]string{"price": {productEntity.CurrencyMessage(currency)}},
+			})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrs, nil
+}
+
+func parseCSV(content []byte, currency string) ([]entity.ImportRow, []entity.ImportRowError, error) {
+	var (
+		reader  = csv.NewReader(bytes.NewReader(content))
+		rows    = make([]entity.ImportRow, 0)
+		rowErrs = make([]entity.ImportRowError, 0)
+		index   = make(map[string]int)
+	)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.New("header CSV tidak ditemukan")
+	}
+
+	for i, col := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))] = i
+	}
+	for _, col := range requiredColumns {
+		if _, ok := index[col]; !ok {
+			return nil, nil, fmt.Errorf("kolom %s tidak ditemukan pada header", col)
+		}
+	}
+
+	cell := func(record []string, col string) string {
+		i, ok := index[col]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rowErrs = append(rowErrs, entity.ImportRowError{Row: n, Errors: map[string][]string{"row": {err.Error()}}})
+			continue
+		}
+
+		var (
+			row  = entity.ImportRow{Row: n}
+			errs = make(map[string][]string)
+		)
+
+		row.Name = cell(record, "name")
+		row.Brand = cell(record, "brand")
+		row.CategoryId = cell(record, "category_id")
+		row.Description = cell(record, "description")
+		row.DescriptionFormat = cell(record, "description_format")
+		row.ImageUrl = cell(record, "image_url")
+
+		if v := cell(record, "price"); v != "" {
+			if row.Price, err = types.ParseMoney(v, currency); err != nil {
+				errs["price"] = append(errs["price"], "price harus angka.")
+			}
+		}
+		if v := cell(record, "stock"); v != "" {
+			if row.Stock, err = strconv.Atoi(v); err != nil {
+				errs["stock"] = append(errs["stock"], "stock harus angka.")
+			}
+		}
+
+		if len(errs) > 0 {
+			rowErrs = append(rowErrs, entity.ImportRowError{Row: n, Errors: errs})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrs, nil
+}
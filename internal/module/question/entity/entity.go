@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+type QuestionItem struct {
+	Id          string       `json:"id" db:"id"`
+	ProductId   string       `json:"product_id" db:"product_id"`
+	UserId      string       `json:"user_id" db:"user_id"`
+	Body        string       `json:"body" db:"body"`
+	UpvoteCount int          `json:"upvote_count" db:"upvote_count"`
+	Answers     []AnswerItem `json:"answers" db:"-"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+}
+
+// AnswerItem is official when it was written by the owner of the product's
+// shop.
+type AnswerItem struct {
+	Id         string    `json:"id" db:"id"`
+	QuestionId string    `json:"question_id" db:"question_id"`
+	UserId     string    `json:"user_id" db:"user_id"`
+	Body       string    `json:"body" db:"body"`
+	IsOfficial bool      `json:"is_official" db:"is_official"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+type AskQuestionRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ProductId string `params:"id" validate:"uuid"`
+	Body      string `json:"body" validate:"required,min=5,max=1000"`
+}
+
+type AnswerQuestionRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	QuestionId string `params:"id" validate:"uuid"`
+	Body       string `json:"body" validate:"required,max=2000"`
+}
+
+type VoteQuestionRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+}
+
+type GetQuestionsRequest struct {
+	ProductId string `params:"id" validate:"uuid"`
+	Sort      string `query:"sort" validate:"omitempty,oneof=top newest"`
+	Page      int    `query:"page" validate:"required"`
+	Paginate  int    `query:"paginate" validate:"required"`
+}
+
+func (r *GetQuestionsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type GetQuestionsResponse struct {
+	Items []QuestionItem `json:"items"`
+	Meta  types.Meta     `json:"meta"`
+}
+
+type ProductOwnership struct {
+	Id         string `db:"id"`
+	Name       string `db:"name"`
+	ShopUserId string `db:"shop_user_id"`
+	Status     string `db:"status"`
+}
+
+// QuestionOwnership tells who asked the question and who owns the shop of
+// the product it is about.
+type QuestionOwnership struct {
+	Id          string `db:"id"`
+	ProductId   string `db:"product_id"`
+	ProductName string `db:"product_name"`
+	UserId      string `db:"user_id"`
+	ShopUserId  string `db:"shop_user_id"`
+}
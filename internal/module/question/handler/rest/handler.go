@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	notificationRepository "codebase-app/internal/module/notification/repository"
+	notificationService "codebase-app/internal/module/notification/service"
+	"codebase-app/internal/module/question/entity"
+	"codebase-app/internal/module/question/ports"
+	"codebase-app/internal/module/question/repository"
+	"codebase-app/internal/module/question/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type questionHandler struct {
+	service ports.QuestionService
+}
+
+func NewQuestionHandler() *questionHandler {
+	var (
+		handler      = new(questionHandler)
+		repo         = repository.NewQuestionRepository(adapter.Adapters.ShopeefunPostgres)
+		notification = notificationService.NewNotificationService(notificationRepository.NewNotificationRepository(adapter.Adapters.ShopeefunPostgres))
+		service      = service.NewQuestionService(repo, notification)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *questionHandler) Register(router fiber.Router) {
+	router.Get("/product/:id/questions", h.GetQuestions)
+	router.Post("/product/:id/questions", middleware.UserIdHeader, h.AskQuestion)
+	router.Post("/questions/:id/answers", middleware.UserIdHeader, h.AnswerQuestion)
+	router.Put("/questions/:id/upvote", middleware.UserIdHeader, h.UpvoteQuestion)
+	router.Delete("/questions/:id/upvote", middleware.UserIdHeader, h.RemoveUpvote)
+}
+
+func (h *questionHandler) AskQuestion(c *fiber.Ctx) error {
+	var (
+		req = new(entity.AskQuestionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::AskQuestion - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.ProductId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::AskQuestion - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.AskQuestion(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, "Pertanyaan berhasil dikirim"))
+}
+
+func (h *questionHandler) AnswerQuestion(c *fiber.Ctx) error {
+	var (
+		req = new(entity.AnswerQuestionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::AnswerQuestion - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.QuestionId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::AnswerQuestion - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.AnswerQuestion(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, "Jawaban berhasil dikirim"))
+}
+
+func (h *questionHandler) UpvoteQuestion(c *fiber.Ctx) error {
+	var (
+		req = new(entity.VoteQuestionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpvoteQuestion - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpvoteQuestion(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *questionHandler) RemoveUpvote(c *fiber.Ctx) error {
+	var (
+		req = new(entity.VoteQuestionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::RemoveUpvote - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.RemoveUpvote(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *questionHandler) GetQuestions(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetQuestionsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetQuestions - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ProductId = c.Params("id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetQuestions - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetQuestions(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"codebase-app/internal/module/question/entity"
+	"context"
+)
+
+type QuestionRepository interface {
+	GetProductOwnership(ctx context.Context, productId string) (*entity.ProductOwnership, error)
+	GetQuestionOwnership(ctx context.Context, id string) (*entity.QuestionOwnership, error)
+	CreateQuestion(ctx context.Context, req *entity.AskQuestionRequest) (string, error)
+	CreateAnswer(ctx context.Context, req *entity.AnswerQuestionRequest, official bool) error
+	AddVote(ctx context.Context, req *entity.VoteQuestionRequest) error
+	RemoveVote(ctx context.Context, req *entity.VoteQuestionRequest) error
+	GetQuestion(ctx context.Context, id string) (*entity.QuestionItem, error)
+	GetQuestions(ctx context.Context, req *entity.GetQuestionsRequest) (*entity.GetQuestionsResponse, error)
+}
+
+type QuestionService interface {
+	AskQuestion(ctx context.Context, req *entity.AskQuestionRequest) (*entity.QuestionItem, error)
+	AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.QuestionItem, error)
+	UpvoteQuestion(ctx context.Context, req *entity.VoteQuestionRequest) (*entity.QuestionItem, error)
+	RemoveUpvote(ctx context.Context, req *entity.VoteQuestionRequest) (*entity.QuestionItem, error)
+	GetQuestions(ctx context.Context, req *entity.GetQuestionsRequest) (*entity.GetQuestionsResponse, error)
+}
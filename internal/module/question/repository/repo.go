@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"codebase-app/internal/module/question/entity"
+	"codebase-app/internal/module/question/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.QuestionRepository = &questionRepository{}
+
+const questionColumns = `
+	q.id,
+	q.product_id,
+	q.user_id,
+	q.body,
+	q.upvote_count,
+	q.created_at
+`
+
+type questionRepository struct {
+	db *sqlx.DB
+}
+
+func NewQuestionRepository(db *sqlx.DB) *questionRepository {
+	return &questionRepository{
+		db: db,
+	}
+}
+
+func (r *questionRepository) GetProductOwnership(ctx context.Context, productId string) (*entity.ProductOwnership, error) {
+	var resp = new(entity.ProductOwnership)
+
+	query := `
+		SELECT p.id, p.name, s.user_id as shop_user_id, p.status
+		FROM product p
+		JOIN shops s ON s.id = p.shop_id
+		WHERE p.id = ? AND p.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), productId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::GetProductOwnership - Failed to get product ownership")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *questionRepository) GetQuestionOwnership(ctx context.Context, id string) (*entity.QuestionOwnership, error) {
+	var resp = new(entity.QuestionOwnership)
+
+	query := `
+		SELECT q.id, q.product_id, p.name as product_name, q.user_id, s.user_id as shop_user_id
+		FROM product_questions q
+		JOIN product p ON p.id = q.product_id
+		JOIN shops s ON s.id = p.shop_id
+		WHERE q.id = ? AND q.deleted_at IS NULL AND p.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetQuestionOwnership - Failed to get question ownership")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *questionRepository) CreateQuestion(ctx context.Context, req *entity.AskQuestionRequest) (string, error) {
+	var id string
+
+	query := `INSERT INTO product_questions (product_id, user_id, body) VALUES (?, ?, ?) RETURNING id`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.ProductId, req.UserId, req.Body).Scan(&id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateQuestion - Failed to create question")
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *questionRepository) CreateAnswer(ctx context.Context, req *entity.AnswerQuestionRequest, official bool) error {
+	query := `INSERT INTO product_answers (question_id, user_id, body, is_official) VALUES (?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.QuestionId, req.UserId, req.Body, official)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateAnswer - Failed to create answer")
+		return err
+	}
+
+	return nil
+}
+
+// AddVote records the vote and bumps upvote_count only when the user had not
+// voted yet, so voting twice is a no-op.
+func (r *questionRepository) AddVote(ctx context.Context, req *entity.VoteQuestionRequest) error {
+	query := `
+		WITH vote AS (
+			INSERT INTO product_question_votes (question_id, user_id)
+			VALUES (?, ?)
+			ON CONFLICT (question_id, user_id) DO NOTHING
+			RETURNING question_id
+		)
+		UPDATE product_questions
+		SET upvote_count = upvote_count + 1
+		WHERE id IN (SELECT question_id FROM vote)
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::AddVote - Failed to add vote")
+		return err
+	}
+
+	return nil
+}
+
+func (r *questionRepository) RemoveVote(ctx context.Context, req *entity.VoteQuestionRequest) error {
+	query := `
+		WITH vote AS (
+			DELETE FROM product_question_votes
+			WHERE question_id = ? AND user_id = ?
+			RETURNING question_id
+		)
+		UPDATE product_questions
+		SET upvote_count = upvote_count - 1
+		WHERE id IN (SELECT question_id FROM vote)
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RemoveVote - Failed to remove vote")
+		return err
+	}
+
+	return nil
+}
+
+func (r *questionRepository) GetQuestion(ctx context.Context, id string) (*entity.QuestionItem, error) {
+	var resp = new(entity.QuestionItem)
+
+	query := `SELECT ` + questionColumns + ` FROM product_questions q WHERE q.id = ? AND q.deleted_at IS NULL`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetQuestion - Failed to get question")
+		return nil, err
+	}
+
+	answers, err := r.getAnswers(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	resp.Answers = answers[id]
+
+	return resp, nil
+}
+
+// GetQuestions lists the questions of a product with all their answers.
+// sort=top orders by upvotes, the default is newest first.
+func (r *questionRepository) GetQuestions(ctx context.Context, req *entity.GetQuestionsRequest) (*entity.GetQuestionsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.QuestionItem
+	}
+
+	var (
+		resp = new(entity.GetQuestionsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.QuestionItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(q.id) OVER() as total_data,
+			` + questionColumns + `
+		FROM product_questions q
+		WHERE q.product_id = ? AND q.deleted_at IS NULL
+	`
+
+	if req.Sort == "top" {
+		query += " ORDER BY q.upvote_count DESC, q.created_at DESC, q.id"
+	} else {
+		query += " ORDER BY q.created_at DESC, q.id"
+	}
+	query += " LIMIT ? OFFSET ?"
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), req.ProductId, req.Paginate, (req.Page-1)*req.Paginate)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetQuestions - Failed to get questions")
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(data))
+	for _, d := range data {
+		ids = append(ids, d.Id)
+	}
+
+	answers, err := r.getAnswers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		d.QuestionItem.Answers = answers[d.Id]
+		resp.Items = append(resp.Items, d.QuestionItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// getAnswers returns the answers of the questions keyed by question id,
+// official answers first. Every question gets a non nil slice.
+func (r *questionRepository) getAnswers(ctx context.Context, questionIds []string) (map[string][]entity.AnswerItem, error) {
+	var (
+		resp = make(map[string][]entity.AnswerItem, len(questionIds))
+		data = make([]entity.AnswerItem, 0)
+	)
+
+	for _, id := range questionIds {
+		resp[id] = make([]entity.AnswerItem, 0)
+	}
+
+	if len(questionIds) == 0 {
+		return resp, nil
+	}
+
+	query := `
+		SELECT id, question_id, user_id, body, is_official, created_at
+		FROM product_answers
+		WHERE question_id = ANY(?::uuid[]) AND deleted_at IS NULL
+		ORDER BY is_official DESC, created_at, id
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), pq.Array(questionIds))
+	if err != nil {
+		log.Error().Err(err).Strs("question_ids", questionIds).Msg("repository::getAnswers - Failed to get answers")
+		return nil, err
+	}
+
+	for _, d := range data {
+		resp[d.QuestionId] = append(resp[d.QuestionId], d)
+	}
+
+	return resp, nil
+}
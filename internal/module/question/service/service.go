@@ -0,0 +1,130 @@
+package service
+
+import (
+	notificationEntity "codebase-app/internal/module/notification/entity"
+	notificationPorts "codebase-app/internal/module/notification/ports"
+	"codebase-app/internal/module/question/entity"
+	"codebase-app/internal/module/question/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var _ ports.QuestionService = &questionService{}
+
+type questionService struct {
+	repo         ports.QuestionRepository
+	notification notificationPorts.NotificationService
+}
+
+func NewQuestionService(repo ports.QuestionRepository, notification notificationPorts.NotificationService) *questionService {
+	return &questionService{
+		repo:         repo,
+		notification: notification,
+	}
+}
+
+// AskQuestion posts a public question and notifies the shop owner.
+func (s *questionService) AskQuestion(ctx context.Context, req *entity.AskQuestionRequest) (*entity.QuestionItem, error) {
+	product, err := s.repo.GetProductOwnership(ctx, req.ProductId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && product.Status != "active") {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreateQuestion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.ShopUserId != req.UserId {
+		s.notification.Notify(ctx, &notificationEntity.NotifyRequest{
+			UserId: product.ShopUserId,
+			Type:   notificationEntity.TypeProductQuestion,
+			Title:  "Pertanyaan baru untuk " + product.Name,
+			Body:   req.Body,
+			Data:   map[string]string{"product_id": product.Id, "question_id": id},
+		})
+	}
+
+	return s.repo.GetQuestion(ctx, id)
+}
+
+// AnswerQuestion lets anyone answer. The answer is official only when it
+// comes from the owner of the product's shop, and then the asker is
+// notified.
+func (s *questionService) AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.QuestionItem, error) {
+	question, err := s.getOwnership(ctx, req.QuestionId)
+	if err != nil {
+		return nil, err
+	}
+
+	official := question.ShopUserId == req.UserId
+
+	if err := s.repo.CreateAnswer(ctx, req, official); err != nil {
+		return nil, err
+	}
+
+	if official && question.UserId != req.UserId {
+		s.notification.Notify(ctx, &notificationEntity.NotifyRequest{
+			UserId: question.UserId,
+			Type:   notificationEntity.TypeProductAnswer,
+			Title:  "Penjual menjawab pertanyaan Anda tentang " + question.ProductName,
+			Body:   req.Body,
+			Data:   map[string]string{"product_id": question.ProductId, "question_id": question.Id},
+		})
+	}
+
+	return s.repo.GetQuestion(ctx, req.QuestionId)
+}
+
+func (s *questionService) UpvoteQuestion(ctx context.Context, req *entity.VoteQuestionRequest) (*entity.QuestionItem, error) {
+	if _, err := s.getOwnership(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddVote(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetQuestion(ctx, req.Id)
+}
+
+func (s *questionService) RemoveUpvote(ctx context.Context, req *entity.VoteQuestionRequest) (*entity.QuestionItem, error) {
+	if _, err := s.getOwnership(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RemoveVote(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetQuestion(ctx, req.Id)
+}
+
+func (s *questionService) GetQuestions(ctx context.Context, req *entity.GetQuestionsRequest) (*entity.GetQuestionsResponse, error) {
+	product, err := s.repo.GetProductOwnership(ctx, req.ProductId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && product.Status != "active") {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetQuestions(ctx, req)
+}
+
+func (s *questionService) getOwnership(ctx context.Context, id string) (*entity.QuestionOwnership, error) {
+	question, err := s.repo.GetQuestionOwnership(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Pertanyaan tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return question, nil
+}
@@ -0,0 +1,121 @@
+package entity
+
+import (
+	productEntity "codebase-app/internal/module/product/entity"
+	"codebase-app/pkg/types"
+	"time"
+)
+
+const (
+	StatusPublished = "published"
+	// StatusPending reviews wait for an admin before they are shown.
+	StatusPending = "pending"
+	StatusHidden  = "hidden"
+)
+
+type ReviewItem struct {
+	Id               string     `json:"id" db:"id"`
+	ProductId        string     `json:"product_id" db:"product_id"`
+	UserId           string     `json:"user_id" db:"user_id"`
+	Rating           int        `json:"rating" db:"rating"`
+	Body             string     `json:"body" db:"body"`
+	PhotoUrls        []string   `json:"photo_urls" db:"-"`
+	Status           string     `json:"status" db:"status"`
+	ModerationReason *string    `json:"moderation_reason,omitempty" db:"moderation_reason"`
+	SellerReply      *string    `json:"seller_reply" db:"seller_reply"`
+	SellerRepliedAt  *time.Time `json:"seller_replied_at" db:"seller_replied_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// ReviewContent holds the fields a buyer writes, shared by create and
+// update.
+type ReviewContent struct {
+	Rating    int      `json:"rating" validate:"required,min=1,max=5"`
+	Body      string   `json:"body" validate:"max=2000"`
+	PhotoUrls []string `json:"photo_urls" validate:"max=5,dive,url,max=2048"`
+}
+
+type CreateReviewRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ProductId string `params:"id" validate:"uuid"`
+	ReviewContent
+}
+
+type UpdateReviewRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+	ReviewContent
+}
+
+type DeleteReviewRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+}
+
+type ReplyReviewRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id    string `params:"id" validate:"uuid"`
+	Reply string `json:"reply" validate:"required,max=1000"`
+}
+
+type ModerateReviewRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Id     string `params:"id" validate:"uuid"`
+	Status string `json:"status" validate:"required,oneof=published hidden"`
+	Reason string `json:"reason" validate:"required_if=Status hidden,max=255"`
+}
+
+// ModerationResult is what a ReviewModerator decides for new or edited
+// review content.
+type ModerationResult struct {
+	Status string
+	Reason string
+}
+
+type GetReviewsRequest struct {
+	ProductId  string `params:"id" validate:"uuid"`
+	Rating     int    `query:"rating" validate:"omitempty,min=1,max=5"`
+	WithPhotos bool   `query:"with_photos"`
+	Sort       string `query:"sort" validate:"omitempty,oneof=newest oldest rating_desc rating_asc"`
+	Page       int    `query:"page" validate:"required"`
+	Paginate   int    `query:"paginate" validate:"required"`
+}
+
+func (r *GetReviewsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type GetReviewsResponse struct {
+	Summary productEntity.RatingSummary `json:"summary"`
+	Items   []ReviewItem                `json:"items"`
+	Meta    types.Meta                  `json:"meta"`
+}
+
+// ReviewOwnership is used to authorize changes to a review: UserId wrote
+// it, ShopUserId owns the shop of the product.
+type ReviewOwnership struct {
+	Id         string `db:"id"`
+	ProductId  string `db:"product_id"`
+	UserId     string `db:"user_id"`
+	ShopUserId string `db:"shop_user_id"`
+	Status     string `db:"status"`
+}
+
+// ProductOwnership is used to check the product can be reviewed.
+type ProductOwnership struct {
+	Id         string `db:"id"`
+	ShopUserId string `db:"shop_user_id"`
+	Status     string `db:"status"`
+}
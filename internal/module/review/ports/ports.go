@@ -0,0 +1,35 @@
+package ports
+
+import (
+	productEntity "codebase-app/internal/module/product/entity"
+	"codebase-app/internal/module/review/entity"
+	"context"
+)
+
+type ReviewRepository interface {
+	GetProductOwnership(ctx context.Context, productId string) (*entity.ProductOwnership, error)
+	GetReviewOwnership(ctx context.Context, id string) (*entity.ReviewOwnership, error)
+	CreateReview(ctx context.Context, req *entity.CreateReviewRequest, moderation *entity.ModerationResult) (string, error)
+	UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest, moderation *entity.ModerationResult) error
+	DeleteReview(ctx context.Context, id string) error
+	ModerateReview(ctx context.Context, req *entity.ModerateReviewRequest) error
+	ReplyReview(ctx context.Context, req *entity.ReplyReviewRequest) error
+	GetReview(ctx context.Context, id string) (*entity.ReviewItem, error)
+	GetReviews(ctx context.Context, req *entity.GetReviewsRequest) (*entity.GetReviewsResponse, error)
+	GetRatingSummary(ctx context.Context, productId string) (productEntity.RatingSummary, error)
+}
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.ReviewItem, error)
+	UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.ReviewItem, error)
+	DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error
+	ReplyReview(ctx context.Context, req *entity.ReplyReviewRequest) (*entity.ReviewItem, error)
+	ModerateReview(ctx context.Context, req *entity.ModerateReviewRequest) (*entity.ReviewItem, error)
+	GetReviews(ctx context.Context, req *entity.GetReviewsRequest) (*entity.GetReviewsResponse, error)
+}
+
+// ReviewModerator screens review content before it is saved. It decides
+// whether the review is published right away, held for an admin or hidden.
+type ReviewModerator interface {
+	Screen(ctx context.Context, productId string, content *entity.ReviewContent) (*entity.ModerationResult, error)
+}
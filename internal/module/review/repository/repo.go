@@ -0,0 +1,417 @@
+package repository
+
+import (
+	productEntity "codebase-app/internal/module/product/entity"
+	"codebase-app/internal/module/review/entity"
+	"codebase-app/internal/module/review/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.ReviewRepository = &reviewRepository{}
+
+const reviewColumns = `
+	r.id,
+	r.product_id,
+	r.user_id,
+	r.rating,
+	r.body,
+	r.photo_urls,
+	r.status,
+	r.moderation_reason,
+	r.seller_reply,
+	r.seller_replied_at,
+	r.created_at,
+	r.updated_at
+`
+
+type reviewDao struct {
+	entity.ReviewItem
+	PhotoUrls pq.StringArray `db:"photo_urls"`
+}
+
+func (d reviewDao) item() entity.ReviewItem {
+	item := d.ReviewItem
+	item.PhotoUrls = []string(d.PhotoUrls)
+
+	return item
+}
+
+// reviewState is what decides whether a review counts towards the product
+// rating.
+type reviewState struct {
+	ProductId string `db:"product_id"`
+	Rating    int    `db:"rating"`
+	Status    string `db:"status"`
+	Deleted   bool   `db:"deleted"`
+}
+
+func (s reviewState) counted() bool {
+	return s.Status == entity.StatusPublished && !s.Deleted
+}
+
+type reviewRepository struct {
+	db *sqlx.DB
+}
+
+func NewReviewRepository(db *sqlx.DB) *reviewRepository {
+	return &reviewRepository{
+		db: db,
+	}
+}
+
+func (r *reviewRepository) GetProductOwnership(ctx context.Context, productId string) (*entity.ProductOwnership, error) {
+	var resp = new(entity.ProductOwnership)
+
+	query := `
+		SELECT p.id, s.user_id as shop_user_id, p.status
+		FROM product p
+		JOIN shops s ON s.id = p.shop_id
+		WHERE p.id = ? AND p.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), productId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::GetProductOwnership - Failed to get product ownership")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *reviewRepository) GetReviewOwnership(ctx context.Context, id string) (*entity.ReviewOwnership, error) {
+	var resp = new(entity.ReviewOwnership)
+
+	query := `
+		SELECT r.id, r.product_id, r.user_id, s.user_id as shop_user_id, r.status
+		FROM reviews r
+		JOIN product p ON p.id = r.product_id
+		JOIN shops s ON s.id = p.shop_id
+		WHERE r.id = ? AND r.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetReviewOwnership - Failed to get review ownership")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *reviewRepository) CreateReview(ctx context.Context, req *entity.CreateReviewRequest, moderation *entity.ModerationResult) (string, error) {
+	var id string
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::CreateReview - Failed to begin transaction")
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::CreateReview - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	query := `
+		INSERT INTO reviews (product_id, user_id, rating, body, photo_urls, status, moderation_reason)
+		VALUES (?, ?, ?, ?, ?, ?, NULLIF(?, ''))
+		RETURNING id
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		req.ProductId, req.UserId, req.Rating, req.Body, pq.Array(req.PhotoUrls), moderation.Status, moderation.Reason,
+	).Scan(&id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateReview - Failed to insert review")
+		return "", err
+	}
+
+	state := reviewState{ProductId: req.ProductId, Rating: req.Rating, Status: moderation.Status}
+	if err = applyStats(ctx, tx, reviewState{}, state); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateReview - Failed to commit transaction")
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *reviewRepository) UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest, moderation *entity.ModerationResult) error {
+	query := `
+		UPDATE reviews
+		SET
+			rating = ?,
+			body = ?,
+			photo_urls = ?,
+			status = ?,
+			moderation_reason = NULLIF(?, ''),
+			updated_at = NOW()
+		WHERE id = ?
+		RETURNING product_id, rating, status, deleted_at IS NOT NULL as deleted
+	`
+
+	return r.change(ctx, req.Id, "UpdateReview", query,
+		req.Rating, req.Body, pq.Array(req.PhotoUrls), moderation.Status, moderation.Reason, req.Id,
+	)
+}
+
+func (r *reviewRepository) DeleteReview(ctx context.Context, id string) error {
+	query := `
+		UPDATE reviews
+		SET deleted_at = NOW()
+		WHERE id = ?
+		RETURNING product_id, rating, status, deleted_at IS NOT NULL as deleted
+	`
+
+	return r.change(ctx, id, "DeleteReview", query, id)
+}
+
+func (r *reviewRepository) ModerateReview(ctx context.Context, req *entity.ModerateReviewRequest) error {
+	query := `
+		UPDATE reviews
+		SET
+			status = ?,
+			moderation_reason = NULLIF(?, ''),
+			moderated_by = ?,
+			moderated_at = NOW(),
+			updated_at = NOW()
+		WHERE id = ?
+		RETURNING product_id, rating, status, deleted_at IS NOT NULL as deleted
+	`
+
+	return r.change(ctx, req.Id, "ModerateReview", query, req.Status, req.Reason, req.UserId, req.Id)
+}
+
+// change locks the review, runs query, which must return the new
+// reviewState, and moves the review between the product aggregates in the
+// same transaction.
+func (r *reviewRepository) change(ctx context.Context, id, op, query string, args ...interface{}) error {
+	var before, after reviewState
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("repository::%s - Failed to begin transaction", op)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msgf("repository::%s - Failed to rollback transaction", op)
+			}
+		}
+	}()
+
+	lock := `
+		SELECT product_id, rating, status, deleted_at IS NOT NULL as deleted
+		FROM reviews
+		WHERE id = ?
+		FOR UPDATE
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(lock), id).StructScan(&before)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msgf("repository::%s - Failed to lock review", op)
+		return err
+	}
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query), args...).StructScan(&after)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msgf("repository::%s - Failed to update review", op)
+		return err
+	}
+
+	if err = applyStats(ctx, tx, before, after); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Str("id", id).Msgf("repository::%s - Failed to commit transaction", op)
+		return err
+	}
+
+	return nil
+}
+
+// applyStats removes before from and adds after to the product aggregates,
+// for whichever of them counts.
+func applyStats(ctx context.Context, tx *sqlx.Tx, before, after reviewState) error {
+	var (
+		productId = after.ProductId
+		count     int
+		sum       int
+		ratings   [5]int
+	)
+
+	if before.counted() {
+		count--
+		sum -= before.Rating
+		ratings[before.Rating-1]--
+	}
+	if after.counted() {
+		count++
+		sum += after.Rating
+		ratings[after.Rating-1]++
+	}
+
+	if count == 0 && ratings == [5]int{} {
+		return nil
+	}
+
+	query := `
+		INSERT INTO product_rating_stats (product_id, rating_count, rating_sum, rating_1, rating_2, rating_3, rating_4, rating_5)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (product_id) DO UPDATE
+		SET
+			rating_count = product_rating_stats.rating_count + EXCLUDED.rating_count,
+			rating_sum = product_rating_stats.rating_sum + EXCLUDED.rating_sum,
+			rating_1 = product_rating_stats.rating_1 + EXCLUDED.rating_1,
+			rating_2 = product_rating_stats.rating_2 + EXCLUDED.rating_2,
+			rating_3 = product_rating_stats.rating_3 + EXCLUDED.rating_3,
+			rating_4 = product_rating_stats.rating_4 + EXCLUDED.rating_4,
+			rating_5 = product_rating_stats.rating_5 + EXCLUDED.rating_5,
+			updated_at = NOW()
+	`
+
+	_, err := tx.ExecContext(ctx, tx.Rebind(query),
+		productId, count, sum, ratings[0], ratings[1], ratings[2], ratings[3], ratings[4],
+	)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::applyStats - Failed to update rating stats")
+		return err
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) ReplyReview(ctx context.Context, req *entity.ReplyReviewRequest) error {
+	query := `
+		UPDATE reviews
+		SET seller_reply = ?, seller_replied_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Reply, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReplyReview - Failed to reply review")
+		return err
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) GetReview(ctx context.Context, id string) (*entity.ReviewItem, error) {
+	var data reviewDao
+
+	query := `SELECT ` + reviewColumns + ` FROM reviews r WHERE r.id = ? AND r.deleted_at IS NULL`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(&data)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetReview - Failed to get review")
+		return nil, err
+	}
+
+	item := data.item()
+	return &item, nil
+}
+
+// GetReviews lists the published reviews of a product.
+func (r *reviewRepository) GetReviews(ctx context.Context, req *entity.GetReviewsRequest) (*entity.GetReviewsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		reviewDao
+	}
+
+	var (
+		resp = new(entity.GetReviewsResponse)
+		data = make([]dao, 0, req.Paginate)
+		args = []interface{}{req.ProductId}
+	)
+	resp.Items = make([]entity.ReviewItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(r.id) OVER() as total_data,
+			` + reviewColumns + `
+		FROM reviews r
+		WHERE r.product_id = ? AND r.status = 'published' AND r.deleted_at IS NULL
+	`
+
+	if req.Rating > 0 {
+		query += " AND r.rating = ?"
+		args = append(args, req.Rating)
+	}
+	if req.WithPhotos {
+		query += " AND cardinality(r.photo_urls) > 0"
+	}
+
+	switch req.Sort {
+	case "oldest":
+		query += " ORDER BY r.created_at ASC, r.id"
+	case "rating_desc":
+		query += " ORDER BY r.rating DESC, r.created_at DESC, r.id"
+	case "rating_asc":
+		query += " ORDER BY r.rating ASC, r.created_at DESC, r.id"
+	default:
+		query += " ORDER BY r.created_at DESC, r.id"
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, req.Paginate, (req.Page-1)*req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetReviews - Failed to get reviews")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.reviewDao.item())
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *reviewRepository) GetRatingSummary(ctx context.Context, productId string) (productEntity.RatingSummary, error) {
+	var (
+		count, sum int
+		ratings    [5]int
+	)
+
+	query := `
+		SELECT
+			COALESCE(MAX(rating_count), 0),
+			COALESCE(MAX(rating_sum), 0),
+			COALESCE(MAX(rating_1), 0),
+			COALESCE(MAX(rating_2), 0),
+			COALESCE(MAX(rating_3), 0),
+			COALESCE(MAX(rating_4), 0),
+			COALESCE(MAX(rating_5), 0)
+		FROM product_rating_stats
+		WHERE product_id = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), productId).Scan(
+		&count, &sum, &ratings[0], &ratings[1], &ratings[2], &ratings[3], &ratings[4],
+	)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::GetRatingSummary - Failed to get rating summary")
+		return productEntity.RatingSummary{}, err
+	}
+
+	return productEntity.NewRatingSummary(count, sum, ratings), nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"codebase-app/internal/module/review/entity"
+	"codebase-app/internal/module/review/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var _ ports.ReviewService = &reviewService{}
+
+type reviewService struct {
+	repo      ports.ReviewRepository
+	moderator ports.ReviewModerator
+}
+
+func NewReviewService(repo ports.ReviewRepository, moderator ports.ReviewModerator) *reviewService {
+	return &reviewService{
+		repo:      repo,
+		moderator: moderator,
+	}
+}
+
+// CreateReview allows one review per user per product. Sellers cannot review
+// the products of their own shop.
+func (s *reviewService) CreateReview(ctx context.Context, req *entity.CreateReviewRequest) (*entity.ReviewItem, error) {
+	product, err := s.repo.GetProductOwnership(ctx, req.ProductId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && product.Status != "active") {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if product.ShopUserId == req.UserId {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak dapat mengulas produk toko sendiri"))
+	}
+
+	moderation, err := s.moderator.Screen(ctx, req.ProductId, &req.ReviewContent)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreateReview(ctx, req, moderation)
+	if err != nil {
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code.Name() == "unique_violation" {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Anda sudah mengulas produk ini"))
+		}
+		return nil, err
+	}
+
+	return s.repo.GetReview(ctx, id)
+}
+
+// UpdateReview screens the new content again. A review hidden by an admin
+// cannot be edited.
+func (s *reviewService) UpdateReview(ctx context.Context, req *entity.UpdateReviewRequest) (*entity.ReviewItem, error) {
+	review, err := s.authorizeAuthor(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.Status == entity.StatusHidden {
+		return nil, errmsg.NewCustomErrors(422, errmsg.WithMessage("Ulasan yang disembunyikan tidak dapat diubah"))
+	}
+
+	moderation, err := s.moderator.Screen(ctx, review.ProductId, &req.ReviewContent)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateReview(ctx, req, moderation); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetReview(ctx, req.Id)
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, req *entity.DeleteReviewRequest) error {
+	if _, err := s.authorizeAuthor(ctx, req.Id, req.UserId); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteReview(ctx, req.Id)
+}
+
+// ReplyReview sets the seller reply, replacing an earlier one.
+func (s *reviewService) ReplyReview(ctx context.Context, req *entity.ReplyReviewRequest) (*entity.ReviewItem, error) {
+	review, err := s.getOwnership(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.ShopUserId != req.UserId {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Hanya pemilik toko yang dapat membalas ulasan ini"))
+	}
+
+	if err := s.repo.ReplyReview(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetReview(ctx, req.Id)
+}
+
+func (s *reviewService) ModerateReview(ctx context.Context, req *entity.ModerateReviewRequest) (*entity.ReviewItem, error) {
+	if _, err := s.getOwnership(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	if req.Status == entity.StatusPublished {
+		req.Reason = ""
+	}
+
+	if err := s.repo.ModerateReview(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetReview(ctx, req.Id)
+}
+
+func (s *reviewService) GetReviews(ctx context.Context, req *entity.GetReviewsRequest) (*entity.GetReviewsResponse, error) {
+	product, err := s.repo.GetProductOwnership(ctx, req.ProductId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && product.Status != "active") {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.GetReviews(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Summary, err = s.repo.GetRatingSummary(ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *reviewService) authorizeAuthor(ctx context.Context, id, userId string) (*entity.ReviewOwnership, error) {
+	review, err := s.getOwnership(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.UserId != userId {
+		return nil, errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke ulasan ini"))
+	}
+
+	return review, nil
+}
+
+func (s *reviewService) getOwnership(ctx context.Context, id string) (*entity.ReviewOwnership, error) {
+	review, err := s.repo.GetReviewOwnership(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Ulasan tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
@@ -8,6 +8,8 @@ type CreateShopRequest struct {
 	Name        string `json:"name" validate:"required,min=3,max=100" db:"name"`
 	Description string `json:"description" validate:"required" db:"description"`
 	Terms       string `json:"terms" validate:"required" db:"terms"`
+	// Currency is the ISO 4217 code the shop's prices are in, IDR when empty.
+	Currency string `json:"currency" validate:"omitempty,currency" db:"currency"`
 }
 
 type CreateShopResponse struct {
@@ -23,6 +25,7 @@ type GetShopResponse struct {
 	Name        string `json:"name" db:"name"`
 	Description string `json:"description" db:"description"`
 	Terms       string `json:"terms" db:"terms"`
+	Currency    string `json:"currency" db:"currency"`
 }
 
 type DeleteShopRequest struct {
@@ -3,6 +3,8 @@ package handler
 import (
 	"codebase-app/internal/adapter"
 	"codebase-app/internal/middleware"
+	auditRepository "codebase-app/internal/module/audit/repository"
+	auditService "codebase-app/internal/module/audit/service"
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
 	"codebase-app/internal/module/shop/repository"
@@ -22,7 +24,8 @@ func NewShopHandler() *shopHandler {
 	var (
 		handler = new(shopHandler)
 		repo    = repository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
-		service = service.NewShopService(repo)
+		audit   = auditService.NewAuditService(auditRepository.NewAuditRepository(adapter.Adapters.ShopeefunPostgres))
+		service = service.NewShopService(repo, audit)
 	)
 	handler.service = service
 
@@ -31,7 +34,7 @@ func NewShopHandler() *shopHandler {
 
 func (h *shopHandler) Register(router fiber.Router) {
 	router.Get("/shops", middleware.UserIdHeader, h.GetShops)
-	router.Post("/shops", middleware.UserIdHeader, h.CreateShop)
+	router.Post("/shops", middleware.UserIdHeader, middleware.IdempotencyKey, h.CreateShop)
 	router.Get("/shops/:id", h.GetShop)
 	router.Delete("/shops/:id", middleware.UserIdHeader, h.DeleteShop)
 	router.Patch("/shops/:id", middleware.UserIdHeader, h.UpdateShop)
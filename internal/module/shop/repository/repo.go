@@ -24,15 +24,16 @@ func NewShopRepository(db *sqlx.DB) *shopRepository {
 func (r *shopRepository) CreateShop(ctx context.Context, req *entity.CreateShopRequest) (*entity.CreateShopResponse, error) {
 	var resp = new(entity.CreateShopResponse)
 	var (
-		query = `INSERT INTO shops (user_id, name, description, terms)
-		VALUES (?, ?, ?, ?) RETURNING id`
+		query = `INSERT INTO shops (user_id, name, description, terms, currency)
+		VALUES (?, ?, ?, ?, COALESCE(NULLIF(UPPER(?), ''), 'IDR')) RETURNING id`
 	)
 
 	err := r.db.QueryRowContext(ctx, r.db.Rebind(query),
 		req.UserId,
 		req.Name,
 		req.Description,
-		req.Terms).Scan(&resp.Id)
+		req.Terms,
+		req.Currency).Scan(&resp.Id)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to create shop")
 		return nil, err
@@ -45,7 +46,7 @@ func (r *shopRepository) GetShop(ctx context.Context, req *entity.GetShopRequest
 	var resp = new(entity.GetShopResponse)
 	// Your code here
 	query := `
-		SELECT name, description, terms
+		SELECT id, name, description, terms, currency
 		FROM shops
 		WHERE id = ?
 	`
@@ -1,6 +1,8 @@
 package service
 
 import (
+	auditEntity "codebase-app/internal/module/audit/entity"
+	auditPorts "codebase-app/internal/module/audit/ports"
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
 	"context"
@@ -9,17 +11,33 @@ import (
 var _ ports.ShopService = &shopService{}
 
 type shopService struct {
-	repo ports.ShopRepository
+	repo  ports.ShopRepository
+	audit auditPorts.AuditService
 }
 
-func NewShopService(repo ports.ShopRepository) *shopService {
+func NewShopService(repo ports.ShopRepository, audit auditPorts.AuditService) *shopService {
 	return &shopService{
-		repo: repo,
+		repo:  repo,
+		audit: audit,
 	}
 }
 
 func (s *shopService) CreateShop(ctx context.Context, req *entity.CreateShopRequest) (*entity.CreateShopResponse, error) {
-	return s.repo.CreateShop(ctx, req)
+	resp, err := s.repo.CreateShop(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	after, _ := s.repo.GetShop(ctx, &entity.GetShopRequest{Id: resp.Id})
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityShop,
+		EntityId: resp.Id,
+		Action:   auditEntity.ActionCreate,
+		After:    after,
+	})
+
+	return resp, nil
 }
 
 func (s *shopService) GetShop(ctx context.Context, req *entity.GetShopRequest) (*entity.GetShopResponse, error) {
@@ -27,13 +45,44 @@ func (s *shopService) GetShop(ctx context.Context, req *entity.GetShopRequest) (
 }
 
 func (s *shopService) DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error {
-	return s.repo.DeleteShop(ctx, req)
+	before, _ := s.repo.GetShop(ctx, &entity.GetShopRequest{Id: req.Id})
+
+	if err := s.repo.DeleteShop(ctx, req); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityShop,
+		EntityId: req.Id,
+		Action:   auditEntity.ActionDelete,
+		Before:   before,
+	})
+
+	return nil
 }
 
 func (s *shopService) UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error) {
-	return s.repo.UpdateShop(ctx, req)
+	before, _ := s.repo.GetShop(ctx, &entity.GetShopRequest{Id: req.Id})
+
+	resp, err := s.repo.UpdateShop(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	after, _ := s.repo.GetShop(ctx, &entity.GetShopRequest{Id: resp.Id})
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityShop,
+		EntityId: resp.Id,
+		Action:   auditEntity.ActionUpdate,
+		Before:   before,
+		After:    after,
+	})
+
+	return resp, nil
 }
 
 func (s *shopService) GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error) {
 	return s.repo.GetShops(ctx, req)
-}
\ No newline at end of file
+}
@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+// MaxUrlsPerFile is the most URLs the sitemap protocol allows in one file.
+const MaxUrlsPerFile = 50000
+
+// IndexFilename is the sitemap index listing every generated sitemap file.
+const IndexFilename = "sitemap.xml"
+
+// ProductPage is an active product, found on the storefront by the slug
+// within its shop.
+type ProductPage struct {
+	ShopId    string    `db:"shop_id"`
+	Slug      string    `db:"slug"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ShopPage and CategoryPage are a live shop and category.
+type ShopPage struct {
+	Id        string    `db:"id"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type CategoryPage struct {
+	Id        string    `db:"id"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type GenerateRequest struct {
+	// Dir is where the files are written. Files of a previous run that are
+	// no longer part of the sitemap are removed.
+	Dir string
+	// StorefrontUrl prefixes the page paths, FilesUrl the sitemap files
+	// in the index: the URL Dir is served from.
+	StorefrontUrl string
+	FilesUrl      string
+	// UrlsPerFile splits the pages of a kind into files of at most that many
+	// URLs, at most MaxUrlsPerFile.
+	UrlsPerFile int
+}
+
+type GenerateResponse struct {
+	Index       string     `json:"index"`
+	Files       []FileItem `json:"files"`
+	GeneratedAt time.Time  `json:"generated_at"`
+}
+
+type FileItem struct {
+	Name string `json:"name"`
+	Urls int    `json:"urls"`
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/sitemap/entity"
+	"codebase-app/internal/module/sitemap/ports"
+	"codebase-app/internal/module/sitemap/repository"
+	"codebase-app/internal/module/sitemap/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// sitemapName matches the index and the sitemap files Generate writes.
+var sitemapName = regexp.MustCompile(`^sitemap(-[a-z]+-[0-9]+)?\.xml$`)
+
+type sitemapHandler struct {
+	service ports.SitemapService
+}
+
+func NewSitemapHandler() *sitemapHandler {
+	var (
+		handler = new(sitemapHandler)
+		repo    = repository.NewSitemapRepository(adapter.Adapters.ShopeefunPostgres)
+		service = service.NewSitemapService(repo)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *sitemapHandler) Register(router fiber.Router) {
+	router.Post("/sitemaps", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.GenerateSitemap)
+	router.Get("/sitemaps/:name", h.GetSitemap)
+}
+
+func (h *sitemapHandler) GenerateSitemap(c *fiber.Ctx) error {
+	req := &entity.GenerateRequest{
+		Dir:           config.Envs.Sitemap.Dir,
+		StorefrontUrl: config.Envs.Sitemap.StorefrontURL,
+		FilesUrl:      config.Envs.Sitemap.FilesURL,
+		UrlsPerFile:   config.Envs.Sitemap.UrlsPerFile,
+	}
+
+	resp, err := h.service.Generate(c.Context(), req)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("handler::GenerateSitemap - Failed to generate sitemap")
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Sitemap berhasil dibuat"))
+}
+
+// GetSitemap serves the files of the last generated sitemap; the index is
+// sitemap.xml.
+func (h *sitemapHandler) GetSitemap(c *fiber.Ctx) error {
+	name := c.Params("name")
+	if !sitemapName.MatchString(name) {
+		return c.Status(fiber.StatusNotFound).JSON(response.Error("Sitemap tidak ditemukan"))
+	}
+
+	path := filepath.Join(config.Envs.Sitemap.Dir, name)
+	if _, err := os.Stat(path); err != nil {
+		log.Warn().Err(err).Str("file", path).Msg("handler::GetSitemap - Sitemap not found")
+		return c.Status(fiber.StatusNotFound).JSON(response.Error("Sitemap tidak ditemukan"))
+	}
+
+	return c.SendFile(path)
+}
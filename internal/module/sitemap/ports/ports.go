@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"codebase-app/internal/module/sitemap/entity"
+	"context"
+)
+
+type SitemapRepository interface {
+	EachProduct(ctx context.Context, fn func(page *entity.ProductPage) error) error
+	EachShop(ctx context.Context, fn func(page *entity.ShopPage) error) error
+	EachCategory(ctx context.Context, fn func(page *entity.CategoryPage) error) error
+}
+
+type SitemapService interface {
+	// Generate writes the sitemap index and the sitemap files of all active
+	// products, shops and categories.
+	Generate(ctx context.Context, req *entity.GenerateRequest) (*entity.GenerateResponse, error)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"codebase-app/internal/module/sitemap/entity"
+	"codebase-app/internal/module/sitemap/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.SitemapRepository = &sitemapRepository{}
+
+type sitemapRepository struct {
+	db *sqlx.DB
+}
+
+func NewSitemapRepository(db *sqlx.DB) *sitemapRepository {
+	return &sitemapRepository{
+		db: db,
+	}
+}
+
+// EachProduct streams the active products of live shops to fn.
+func (r *sitemapRepository) EachProduct(ctx context.Context, fn func(page *entity.ProductPage) error) error {
+	query := `
+		SELECT p.shop_id, p.slug, p.updated_at
+		FROM product p
+		JOIN shops s ON s.id = p.shop_id AND s.deleted_at IS NULL
+		WHERE p.deleted_at IS NULL AND p.status = 'active'
+		ORDER BY p.shop_id, p.slug
+	`
+
+	return each(ctx, r.db, "EachProduct", query, new(entity.ProductPage), fn)
+}
+
+// EachShop streams the live shops to fn.
+func (r *sitemapRepository) EachShop(ctx context.Context, fn func(page *entity.ShopPage) error) error {
+	query := `
+		SELECT id, updated_at
+		FROM shops
+		WHERE deleted_at IS NULL
+		ORDER BY id
+	`
+
+	return each(ctx, r.db, "EachShop", query, new(entity.ShopPage), fn)
+}
+
+// EachCategory streams the live categories to fn.
+func (r *sitemapRepository) EachCategory(ctx context.Context, fn func(page *entity.CategoryPage) error) error {
+	query := `
+		SELECT id, updated_at
+		FROM category
+		WHERE deleted_at IS NULL
+		ORDER BY id
+	`
+
+	return each(ctx, r.db, "EachCategory", query, new(entity.CategoryPage), fn)
+}
+
+// each scans the rows of query into page one at a time and passes it to fn,
+// so the catalog is never held in memory.
+func each[T any](ctx context.Context, db *sqlx.DB, name, query string, page *T, fn func(page *T) error) error {
+	rows, err := db.QueryxContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msgf("repository::%s - Failed to query pages", name)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.StructScan(page); err != nil {
+			log.Error().Err(err).Msgf("repository::%s - Failed to scan page", name)
+			return err
+		}
+
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msgf("repository::%s - Failed to iterate pages", name)
+		return err
+	}
+
+	return nil
+}
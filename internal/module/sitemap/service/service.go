@@ -0,0 +1,158 @@
+package service
+
+import (
+	"codebase-app/internal/module/sitemap/entity"
+	"codebase-app/internal/module/sitemap/ports"
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.SitemapService = &sitemapService{}
+
+type sitemapService struct {
+	repo ports.SitemapRepository
+	// mu lets one generation at a time write the directory.
+	mu sync.Mutex
+}
+
+func NewSitemapService(repo ports.SitemapRepository) *sitemapService {
+	return &sitemapService{
+		repo: repo,
+	}
+}
+
+// Generate writes every file under a temporary name first and only renames
+// them into place, the index last, once all were written. A failed run
+// leaves the previous sitemap as it was.
+//
+// Storefront pages are /shops/<shop_id>/products/<slug>, /shops/<shop_id>
+// and /categories/<category_id>.
+func (s *sitemapService) Generate(ctx context.Context, req *entity.GenerateRequest) (*entity.GenerateResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.UrlsPerFile <= 0 || req.UrlsPerFile > entity.MaxUrlsPerFile {
+		req.UrlsPerFile = entity.MaxUrlsPerFile
+	}
+
+	if err := os.MkdirAll(req.Dir, 0o755); err != nil {
+		log.Error().Err(err).Str("dir", req.Dir).Msg("service::Generate - Failed to create sitemap directory")
+		return nil, err
+	}
+
+	var (
+		storefront = strings.TrimRight(req.StorefrontUrl, "/")
+		products   = &urlset{dir: req.Dir, kind: "products", limit: req.UrlsPerFile}
+		shops      = &urlset{dir: req.Dir, kind: "shops", limit: req.UrlsPerFile}
+		categories = &urlset{dir: req.Dir, kind: "categories", limit: req.UrlsPerFile}
+		sets       = []*urlset{products, shops, categories}
+	)
+
+	published := false
+	defer func() {
+		if !published {
+			for _, set := range sets {
+				set.discard()
+			}
+		}
+	}()
+
+	err := s.repo.EachProduct(ctx, func(page *entity.ProductPage) error {
+		return products.add(storefront+"/shops/"+page.ShopId+"/products/"+url.PathEscape(page.Slug), page.UpdatedAt)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("service::Generate - Failed to write product sitemaps")
+		return nil, err
+	}
+
+	err = s.repo.EachShop(ctx, func(page *entity.ShopPage) error {
+		return shops.add(storefront+"/shops/"+page.Id, page.UpdatedAt)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("service::Generate - Failed to write shop sitemaps")
+		return nil, err
+	}
+
+	err = s.repo.EachCategory(ctx, func(page *entity.CategoryPage) error {
+		return categories.add(storefront+"/categories/"+page.Id, page.UpdatedAt)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("service::Generate - Failed to write category sitemaps")
+		return nil, err
+	}
+
+	var (
+		now   = time.Now()
+		files = make([]*sitemapFile, 0)
+		resp  = &entity.GenerateResponse{Index: entity.IndexFilename, Files: make([]entity.FileItem, 0), GeneratedAt: now}
+	)
+
+	index, err := createFile(req.Dir, entity.IndexFilename, "sitemapindex")
+	if err != nil {
+		log.Error().Err(err).Msg("service::Generate - Failed to create sitemap index")
+		return nil, err
+	}
+	defer func() {
+		if !published {
+			index.discard()
+		}
+	}()
+
+	for _, set := range sets {
+		if err := set.close(); err != nil {
+			log.Error().Err(err).Str("kind", set.kind).Msg("service::Generate - Failed to write sitemap")
+			return nil, err
+		}
+
+		for _, sf := range set.files {
+			index.entry("sitemap", strings.TrimRight(req.FilesUrl, "/")+"/"+sf.Name, now)
+			files = append(files, sf)
+			resp.Files = append(resp.Files, sf.FileItem)
+		}
+	}
+
+	if err := index.close("sitemapindex"); err != nil {
+		log.Error().Err(err).Msg("service::Generate - Failed to write sitemap index")
+		return nil, err
+	}
+
+	for _, sf := range append(files, index) {
+		if err := sf.publish(req.Dir); err != nil {
+			log.Error().Err(err).Str("file", sf.Name).Msg("service::Generate - Failed to publish sitemap")
+			return nil, err
+		}
+	}
+	published = true
+
+	removeStale(req.Dir, resp.Files)
+
+	log.Info().Int("files", len(resp.Files)).Str("dir", req.Dir).Msg("service::Generate - Sitemap generated")
+
+	return resp, nil
+}
+
+// removeStale deletes the files of a previous run that had more pages of a
+// kind than this one.
+func removeStale(dir string, files []entity.FileItem) {
+	names, _ := filepath.Glob(filepath.Join(dir, "sitemap-*.xml"))
+	for _, name := range names {
+		stale := !slices.ContainsFunc(files, func(f entity.FileItem) bool {
+			return f.Name == filepath.Base(name)
+		})
+		if !stale {
+			continue
+		}
+
+		if err := os.Remove(name); err != nil {
+			log.Warn().Err(err).Str("file", name).Msg("service::Generate - Failed to remove stale sitemap")
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"bufio"
+	"codebase-app/internal/module/sitemap/entity"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// sitemapFile is a file being written under a temporary name until publish
+// renames it, so readers never see a half written sitemap.
+type sitemapFile struct {
+	entity.FileItem
+	tmp string
+	f   *os.File
+	w   *bufio.Writer
+}
+
+func createFile(dir, name, root string) (*sitemapFile, error) {
+	f, err := os.CreateTemp(dir, "."+name+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	sf := &sitemapFile{
+		FileItem: entity.FileItem{Name: name},
+		tmp:      f.Name(),
+		f:        f,
+		w:        bufio.NewWriter(f),
+	}
+	fmt.Fprintf(sf.w, "%s<%s xmlns=%q>\n", xml.Header, root, xmlns)
+
+	return sf, nil
+}
+
+// entry writes a <url> or <sitemap> element. Write errors stick to the
+// buffered writer and are reported by close.
+func (sf *sitemapFile) entry(tag, loc string, lastmod time.Time) {
+	fmt.Fprintf(sf.w, "<%s><loc>", tag)
+	xml.EscapeText(sf.w, []byte(loc))
+	fmt.Fprintf(sf.w, "</loc><lastmod>%s</lastmod></%s>\n", lastmod.UTC().Format(time.RFC3339), tag)
+	sf.Urls++
+}
+
+func (sf *sitemapFile) close(root string) error {
+	fmt.Fprintf(sf.w, "</%s>\n", root)
+	if err := sf.w.Flush(); err != nil {
+		sf.f.Close()
+		return err
+	}
+
+	return sf.f.Close()
+}
+
+// discard removes the temporary file of an unpublished sitemap.
+func (sf *sitemapFile) discard() {
+	sf.f.Close()
+	os.Remove(sf.tmp)
+}
+
+func (sf *sitemapFile) publish(dir string) error {
+	return os.Rename(sf.tmp, filepath.Join(dir, sf.Name))
+}
+
+// urlset writes the pages of one kind into sitemap-<kind>-<n>.xml files of
+// at most limit URLs each.
+type urlset struct {
+	dir   string
+	kind  string
+	limit int
+	files []*sitemapFile
+}
+
+func (u *urlset) add(loc string, lastmod time.Time) error {
+	if n := len(u.files); n == 0 || u.files[n-1].Urls == u.limit {
+		if n > 0 {
+			if err := u.files[n-1].close("urlset"); err != nil {
+				return err
+			}
+		}
+
+		sf, err := createFile(u.dir, fmt.Sprintf("sitemap-%s-%d.xml", u.kind, n+1), "urlset")
+		if err != nil {
+			return err
+		}
+		u.files = append(u.files, sf)
+	}
+
+	u.files[len(u.files)-1].entry("url", loc, lastmod)
+	return nil
+}
+
+// close finishes the last file; the others are closed once full.
+func (u *urlset) close() error {
+	if n := len(u.files); n > 0 {
+		return u.files[n-1].close("urlset")
+	}
+
+	return nil
+}
+
+func (u *urlset) discard() {
+	for _, sf := range u.files {
+		sf.discard()
+	}
+}
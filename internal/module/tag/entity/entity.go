@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+type TagItem struct {
+	Id           string     `json:"id" db:"id"`
+	Name         string     `json:"name" db:"name"`
+	ProductCount int        `json:"product_count" db:"product_count"`
+	MergedInto   *string    `json:"merged_into" db:"merged_into"`
+	BannedAt     *time.Time `json:"banned_at" db:"banned_at"`
+	BanReason    *string    `json:"ban_reason" db:"ban_reason"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+type GetPopularTagsRequest struct {
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+func (r *GetPopularTagsRequest) SetDefault() {
+	if r.Limit < 1 {
+		r.Limit = 20
+	}
+}
+
+// PopularTag counts only active products.
+type PopularTag struct {
+	Name         string `json:"name" db:"name"`
+	ProductCount int    `json:"product_count" db:"product_count"`
+}
+
+type GetPopularTagsResponse struct {
+	Items []PopularTag `json:"items"`
+}
+
+type GetTagsRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Query    string `query:"q"`
+	Status   string `query:"status" validate:"omitempty,oneof=active banned merged"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *GetTagsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type GetTagsResponse struct {
+	Items []TagItem  `json:"items"`
+	Meta  types.Meta `json:"meta"`
+}
+
+// MergeTagRequest moves every product of the tag to TargetId. The merged
+// tag is kept so its name keeps resolving to the target.
+type MergeTagRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Id       string `params:"id" validate:"uuid"`
+	TargetId string `json:"target_id" validate:"required,uuid"`
+}
+
+// BanTagRequest removes the tag from every product and rejects it on
+// future assignments.
+type BanTagRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Id     string `params:"id" validate:"uuid"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type UnbanTagRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Id string `params:"id" validate:"uuid"`
+}
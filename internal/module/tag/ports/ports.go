@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"codebase-app/internal/module/tag/entity"
+	"context"
+)
+
+type TagRepository interface {
+	GetTag(ctx context.Context, id string) (*entity.TagItem, error)
+	GetTags(ctx context.Context, req *entity.GetTagsRequest) (*entity.GetTagsResponse, error)
+	GetPopularTags(ctx context.Context, req *entity.GetPopularTagsRequest) (*entity.GetPopularTagsResponse, error)
+	MergeTag(ctx context.Context, req *entity.MergeTagRequest) error
+	BanTag(ctx context.Context, req *entity.BanTagRequest) error
+	UnbanTag(ctx context.Context, req *entity.UnbanTagRequest) error
+}
+
+type TagService interface {
+	GetTags(ctx context.Context, req *entity.GetTagsRequest) (*entity.GetTagsResponse, error)
+	GetPopularTags(ctx context.Context, req *entity.GetPopularTagsRequest) (*entity.GetPopularTagsResponse, error)
+	MergeTag(ctx context.Context, req *entity.MergeTagRequest) (*entity.TagItem, error)
+	BanTag(ctx context.Context, req *entity.BanTagRequest) (*entity.TagItem, error)
+	UnbanTag(ctx context.Context, req *entity.UnbanTagRequest) (*entity.TagItem, error)
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"codebase-app/internal/module/tag/entity"
+	"codebase-app/internal/module/tag/ports"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.TagRepository = &tagRepository{}
+
+const tagColumns = `
+	t.id,
+	t.name,
+	(SELECT COUNT(*) FROM product_tags pt WHERE pt.tag_id = t.id) as product_count,
+	t.merged_into,
+	t.banned_at,
+	t.ban_reason,
+	t.created_at
+`
+
+type tagRepository struct {
+	db *sqlx.DB
+}
+
+func NewTagRepository(db *sqlx.DB) *tagRepository {
+	return &tagRepository{
+		db: db,
+	}
+}
+
+func (r *tagRepository) GetTag(ctx context.Context, id string) (*entity.TagItem, error) {
+	var resp = new(entity.TagItem)
+
+	query := `SELECT ` + tagColumns + ` FROM tags t WHERE t.id = ?`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("repository::GetTag - Failed to get tag")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *tagRepository) GetTags(ctx context.Context, req *entity.GetTagsRequest) (*entity.GetTagsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.TagItem
+	}
+
+	var (
+		resp = new(entity.GetTagsResponse)
+		data = make([]dao, 0, req.Paginate)
+		args = make([]interface{}, 0, 4)
+	)
+	resp.Items = make([]entity.TagItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(t.id) OVER() as total_data,
+			` + tagColumns + `
+		FROM tags t
+		WHERE 1 = 1
+	`
+
+	if req.Query != "" {
+		query += " AND t.name ILIKE ?"
+		args = append(args, "%"+req.Query+"%")
+	}
+
+	switch req.Status {
+	case "active":
+		query += " AND t.banned_at IS NULL AND t.merged_into IS NULL"
+	case "banned":
+		query += " AND t.banned_at IS NOT NULL"
+	case "merged":
+		query += " AND t.merged_into IS NOT NULL"
+	}
+
+	query += " ORDER BY t.name LIMIT ? OFFSET ?"
+	args = append(args, req.Paginate, (req.Page-1)*req.Paginate)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), args...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetTags - Failed to get tags")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.TagItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *tagRepository) GetPopularTags(ctx context.Context, req *entity.GetPopularTagsRequest) (*entity.GetPopularTagsResponse, error) {
+	var resp = &entity.GetPopularTagsResponse{Items: make([]entity.PopularTag, 0, req.Limit)}
+
+	query := `
+		SELECT t.name, COUNT(*) as product_count
+		FROM product_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		JOIN product p ON p.id = pt.product_id
+		WHERE
+			t.banned_at IS NULL
+			AND t.merged_into IS NULL
+			AND p.deleted_at IS NULL
+			AND p.status = 'active'
+		GROUP BY t.id, t.name
+		ORDER BY product_count DESC, t.name
+		LIMIT ?
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.Limit)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetPopularTags - Failed to get popular tags")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// MergeTag moves the products of the tag to the target and points the tag,
+// and any tag merged into it earlier, at the target.
+func (r *tagRepository) MergeTag(ctx context.Context, req *entity.MergeTagRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::MergeTag - Failed to begin transaction")
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::MergeTag - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	query := `
+		INSERT INTO product_tags (product_id, tag_id, created_at)
+		SELECT product_id, ?, created_at FROM product_tags WHERE tag_id = ?
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), req.TargetId, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeTag - Failed to move product tags")
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM product_tags WHERE tag_id = ?`), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeTag - Failed to delete product tags")
+		return err
+	}
+
+	query = `
+		UPDATE tags
+		SET merged_into = ?, updated_at = NOW()
+		WHERE id = ? OR merged_into = ?
+	`
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), req.TargetId, req.Id, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeTag - Failed to update tags")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeTag - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (r *tagRepository) BanTag(ctx context.Context, req *entity.BanTagRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::BanTag - Failed to begin transaction")
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::BanTag - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	query := `
+		UPDATE tags
+		SET banned_at = NOW(), banned_by = ?, ban_reason = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(query), req.UserId, req.Reason, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::BanTag - Failed to ban tag")
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM product_tags WHERE tag_id = ?`), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::BanTag - Failed to delete product tags")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::BanTag - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (r *tagRepository) UnbanTag(ctx context.Context, req *entity.UnbanTagRequest) error {
+	query := `
+		UPDATE tags
+		SET banned_at = NULL, banned_by = NULL, ban_reason = NULL, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UnbanTag - Failed to unban tag")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"codebase-app/internal/module/tag/entity"
+	"codebase-app/internal/module/tag/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var _ ports.TagService = &tagService{}
+
+type tagService struct {
+	repo ports.TagRepository
+}
+
+func NewTagService(repo ports.TagRepository) *tagService {
+	return &tagService{
+		repo: repo,
+	}
+}
+
+func (s *tagService) GetTags(ctx context.Context, req *entity.GetTagsRequest) (*entity.GetTagsResponse, error) {
+	return s.repo.GetTags(ctx, req)
+}
+
+func (s *tagService) GetPopularTags(ctx context.Context, req *entity.GetPopularTagsRequest) (*entity.GetPopularTagsResponse, error) {
+	return s.repo.GetPopularTags(ctx, req)
+}
+
+func (s *tagService) MergeTag(ctx context.Context, req *entity.MergeTagRequest) (*entity.TagItem, error) {
+	if req.TargetId == req.Id {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("target_id", "target tag harus berbeda dengan tag yang digabung."))
+	}
+
+	tag, err := s.getTag(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if tag.MergedInto != nil {
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Tag sudah digabung ke tag lain"))
+	}
+
+	target, err := s.repo.GetTag(ctx, req.TargetId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("target_id", "target tag tidak ditemukan."))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if target.MergedInto != nil || target.BannedAt != nil {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("target_id", "target tag sudah digabung atau dilarang."))
+	}
+
+	if err := s.repo.MergeTag(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTag(ctx, req.TargetId)
+}
+
+func (s *tagService) BanTag(ctx context.Context, req *entity.BanTagRequest) (*entity.TagItem, error) {
+	tag, err := s.getTag(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if tag.MergedInto != nil {
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Tag sudah digabung, larang tag tujuannya"))
+	}
+
+	if err := s.repo.BanTag(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTag(ctx, req.Id)
+}
+
+func (s *tagService) UnbanTag(ctx context.Context, req *entity.UnbanTagRequest) (*entity.TagItem, error) {
+	if _, err := s.getTag(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UnbanTag(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTag(ctx, req.Id)
+}
+
+func (s *tagService) getTag(ctx context.Context, id string) (*entity.TagItem, error) {
+	tag, err := s.repo.GetTag(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Tag tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
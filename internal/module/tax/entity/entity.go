@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+// TaxClass is a tax rate, in percent, assigned to categories and shops.
+type TaxClass struct {
+	Id        string    `json:"id" db:"id"`
+	Code      string    `json:"code" db:"code"`
+	Name      string    `json:"name" db:"name"`
+	Rate      string    `json:"rate" db:"rate"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CreateTaxClassRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Code string `json:"code" validate:"required,max=50" db:"code"`
+	Name string `json:"name" validate:"required,max=100" db:"name"`
+	Rate string `json:"rate" validate:"required,numeric" db:"rate"`
+}
+
+type UpdateTaxClassRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	Id   string `params:"id" validate:"uuid" db:"id"`
+	Name string `json:"name" validate:"required,max=100" db:"name"`
+	Rate string `json:"rate" validate:"required,numeric" db:"rate"`
+}
+
+type GetTaxClassesResponse struct {
+	Items []TaxClass `json:"items"`
+}
+
+// SetCategoryTaxClassRequest assigns the default tax class of a category.
+type SetCategoryTaxClassRequest struct {
+	UserId string `prop:"user_id" validate:"required"`
+
+	CategoryId string `params:"id" validate:"uuid"`
+	TaxClassId string `json:"tax_class_id" validate:"required,uuid"`
+}
+
+type GetShopTaxSettingsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ShopId string `params:"id" validate:"uuid"`
+}
+
+// SetShopTaxSettingsRequest overrides the category tax class for every
+// product of the shop. An empty TaxClassId keeps the category tax class.
+type SetShopTaxSettingsRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ShopId           string `params:"id" validate:"uuid"`
+	TaxClassId       string `json:"tax_class_id" validate:"omitempty,uuid"`
+	PricesIncludeTax *bool  `json:"prices_include_tax" validate:"required"`
+}
+
+type ShopTaxSettings struct {
+	ShopId           string     `json:"shop_id" db:"shop_id"`
+	TaxClass         *TaxClass  `json:"tax_class" db:"-"`
+	PricesIncludeTax bool       `json:"prices_include_tax" db:"prices_include_tax"`
+	UpdatedAt        *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ShopOwnership is used to authorize changes to a shop's tax settings.
+type ShopOwnership struct {
+	Id     string `db:"id"`
+	UserId string `db:"user_id"`
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	auditRepository "codebase-app/internal/module/audit/repository"
+	auditService "codebase-app/internal/module/audit/service"
+	"codebase-app/internal/module/tax/entity"
+	"codebase-app/internal/module/tax/ports"
+	"codebase-app/internal/module/tax/repository"
+	"codebase-app/internal/module/tax/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type taxHandler struct {
+	service ports.TaxService
+}
+
+func NewTaxHandler() *taxHandler {
+	var (
+		handler = new(taxHandler)
+		repo    = repository.NewTaxRepository(adapter.Adapters.ShopeefunPostgres)
+		audit   = auditService.NewAuditService(auditRepository.NewAuditRepository(adapter.Adapters.ShopeefunPostgres))
+		service = service.NewTaxService(repo, audit)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *taxHandler) Register(router fiber.Router) {
+	router.Get("/tax-classes", h.GetTaxClasses)
+	router.Post("/tax-classes", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.CreateTaxClass)
+	router.Put("/tax-classes/:id", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.UpdateTaxClass)
+	router.Put("/categories/:id/tax-class", middleware.AuthBearer, middleware.AuthRole([]string{"admin"}), h.SetCategoryTaxClass)
+	router.Get("/shops/:id/tax-settings", middleware.UserIdHeader, h.GetShopTaxSettings)
+	router.Put("/shops/:id/tax-settings", middleware.UserIdHeader, h.SetShopTaxSettings)
+}
+
+func (h *taxHandler) GetTaxClasses(c *fiber.Ctx) error {
+	resp, err := h.service.GetTaxClasses(c.Context())
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *taxHandler) CreateTaxClass(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateTaxClassRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateTaxClass - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateTaxClass - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateTaxClass(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, "Kelas pajak berhasil dibuat"))
+}
+
+func (h *taxHandler) UpdateTaxClass(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpdateTaxClassRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateTaxClass - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateTaxClass - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateTaxClass(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Kelas pajak berhasil diupdate"))
+}
+
+func (h *taxHandler) SetCategoryTaxClass(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetCategoryTaxClassRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetCategoryTaxClass - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.CategoryId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetCategoryTaxClass - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.SetCategoryTaxClass(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Kelas pajak kategori berhasil disimpan"))
+}
+
+func (h *taxHandler) GetShopTaxSettings(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetShopTaxSettingsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.ShopId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetShopTaxSettings - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetShopTaxSettings(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *taxHandler) SetShopTaxSettings(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SetShopTaxSettingsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SetShopTaxSettings - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.ShopId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SetShopTaxSettings - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.SetShopTaxSettings(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Pengaturan pajak toko berhasil disimpan"))
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"codebase-app/internal/module/tax/entity"
+	"context"
+)
+
+type TaxRepository interface {
+	CreateTaxClass(ctx context.Context, req *entity.CreateTaxClassRequest) (*entity.TaxClass, error)
+	UpdateTaxClass(ctx context.Context, req *entity.UpdateTaxClassRequest) (*entity.TaxClass, error)
+	GetTaxClass(ctx context.Context, id string) (*entity.TaxClass, error)
+	GetTaxClasses(ctx context.Context) ([]entity.TaxClass, error)
+	SetCategoryTaxClass(ctx context.Context, req *entity.SetCategoryTaxClassRequest) error
+	GetShopOwnership(ctx context.Context, shopId string) (*entity.ShopOwnership, error)
+	GetShopTaxSettings(ctx context.Context, shopId string) (*entity.ShopTaxSettings, error)
+	SetShopTaxSettings(ctx context.Context, req *entity.SetShopTaxSettingsRequest) error
+}
+
+type TaxService interface {
+	CreateTaxClass(ctx context.Context, req *entity.CreateTaxClassRequest) (*entity.TaxClass, error)
+	UpdateTaxClass(ctx context.Context, req *entity.UpdateTaxClassRequest) (*entity.TaxClass, error)
+	GetTaxClasses(ctx context.Context) (*entity.GetTaxClassesResponse, error)
+	SetCategoryTaxClass(ctx context.Context, req *entity.SetCategoryTaxClassRequest) error
+	GetShopTaxSettings(ctx context.Context, req *entity.GetShopTaxSettingsRequest) (*entity.ShopTaxSettings, error)
+	SetShopTaxSettings(ctx context.Context, req *entity.SetShopTaxSettingsRequest) (*entity.ShopTaxSettings, error)
+}
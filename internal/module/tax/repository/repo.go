@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"codebase-app/internal/module/tax/entity"
+	"codebase-app/internal/module/tax/ports"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.TaxRepository = &taxRepository{}
+
+const taxClassColumns = `id, code, name, rate::text as rate, created_at, updated_at`
+
+type taxRepository struct {
+	db *sqlx.DB
+}
+
+func NewTaxRepository(db *sqlx.DB) *taxRepository {
+	return &taxRepository{
+		db: db,
+	}
+}
+
+func (r *taxRepository) CreateTaxClass(ctx context.Context, req *entity.CreateTaxClassRequest) (*entity.TaxClass, error) {
+	var resp = new(entity.TaxClass)
+
+	query := `
+		INSERT INTO tax_classes (code, name, rate)
+		VALUES (?, ?, CAST(? AS DECIMAL))
+		RETURNING ` + taxClassColumns
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Code, req.Name, req.Rate).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateTaxClass - Failed to create tax class")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *taxRepository) UpdateTaxClass(ctx context.Context, req *entity.UpdateTaxClassRequest) (*entity.TaxClass, error) {
+	var resp = new(entity.TaxClass)
+
+	query := `
+		UPDATE tax_classes
+		SET name = ?, rate = CAST(? AS DECIMAL), updated_at = NOW()
+		WHERE id = ?
+		RETURNING ` + taxClassColumns
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Name, req.Rate, req.Id).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateTaxClass - Failed to update tax class")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *taxRepository) GetTaxClass(ctx context.Context, id string) (*entity.TaxClass, error) {
+	var resp = new(entity.TaxClass)
+
+	query := `SELECT ` + taxClassColumns + ` FROM tax_classes WHERE id = ?`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(resp)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("repository::GetTaxClass - Failed to get tax class")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *taxRepository) GetTaxClasses(ctx context.Context) ([]entity.TaxClass, error) {
+	var resp = make([]entity.TaxClass, 0)
+
+	query := `SELECT ` + taxClassColumns + ` FROM tax_classes ORDER BY code`
+
+	err := r.db.SelectContext(ctx, &resp, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::GetTaxClasses - Failed to get tax classes")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *taxRepository) SetCategoryTaxClass(ctx context.Context, req *entity.SetCategoryTaxClassRequest) error {
+	query := `
+		UPDATE category
+		SET tax_class_id = ?, updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.TaxClassId, req.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetCategoryTaxClass - Failed to set category tax class")
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *taxRepository) GetShopOwnership(ctx context.Context, shopId string) (*entity.ShopOwnership, error) {
+	var resp = new(entity.ShopOwnership)
+
+	query := `SELECT id, user_id FROM shops WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), shopId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetShopOwnership - Failed to get shop ownership")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetShopTaxSettings returns sql.ErrNoRows when the shop has never changed
+// its tax settings.
+func (r *taxRepository) GetShopTaxSettings(ctx context.Context, shopId string) (*entity.ShopTaxSettings, error) {
+	type dao struct {
+		entity.ShopTaxSettings
+		TaxClassId sql.NullString `db:"tax_class_id"`
+	}
+
+	var data dao
+
+	query := `
+		SELECT shop_id, tax_class_id, prices_include_tax, updated_at
+		FROM shop_tax_settings
+		WHERE shop_id = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), shopId).StructScan(&data)
+	if err != nil {
+		log.Warn().Err(err).Str("shop_id", shopId).Msg("repository::GetShopTaxSettings - Failed to get shop tax settings")
+		return nil, err
+	}
+
+	resp := data.ShopTaxSettings
+	if data.TaxClassId.Valid {
+		resp.TaxClass, err = r.GetTaxClass(ctx, data.TaxClassId.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &resp, nil
+}
+
+func (r *taxRepository) SetShopTaxSettings(ctx context.Context, req *entity.SetShopTaxSettingsRequest) error {
+	query := `
+		INSERT INTO shop_tax_settings (shop_id, tax_class_id, prices_include_tax, updated_by)
+		VALUES (?, NULLIF(?, '')::uuid, ?, ?)
+		ON CONFLICT (shop_id) DO UPDATE
+		SET
+			tax_class_id = EXCLUDED.tax_class_id,
+			prices_include_tax = EXCLUDED.prices_include_tax,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.ShopId, req.TaxClassId, *req.PricesIncludeTax, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SetShopTaxSettings - Failed to set shop tax settings")
+		return err
+	}
+
+	return nil
+}
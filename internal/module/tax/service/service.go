@@ -0,0 +1,182 @@
+package service
+
+import (
+	auditEntity "codebase-app/internal/module/audit/entity"
+	auditPorts "codebase-app/internal/module/audit/ports"
+	"codebase-app/internal/module/tax/entity"
+	"codebase-app/internal/module/tax/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var _ ports.TaxService = &taxService{}
+
+type taxService struct {
+	repo  ports.TaxRepository
+	audit auditPorts.AuditService
+}
+
+func NewTaxService(repo ports.TaxRepository, audit auditPorts.AuditService) *taxService {
+	return &taxService{
+		repo:  repo,
+		audit: audit,
+	}
+}
+
+func (s *taxService) CreateTaxClass(ctx context.Context, req *entity.CreateTaxClassRequest) (*entity.TaxClass, error) {
+	req.Code = strings.ToUpper(req.Code)
+
+	if err := validateRate(req.Rate); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.CreateTaxClass(ctx, req)
+	if err != nil {
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code.Name() == "unique_violation" {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("code", "kode kelas pajak sudah digunakan."))
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// UpdateTaxClass changes the name or rate. The code stays fixed because
+// other systems refer to it.
+func (s *taxService) UpdateTaxClass(ctx context.Context, req *entity.UpdateTaxClassRequest) (*entity.TaxClass, error) {
+	if err := validateRate(req.Rate); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.UpdateTaxClass(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Kelas pajak tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *taxService) GetTaxClasses(ctx context.Context) (*entity.GetTaxClassesResponse, error) {
+	items, err := s.repo.GetTaxClasses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.GetTaxClassesResponse{Items: items}, nil
+}
+
+func (s *taxService) SetCategoryTaxClass(ctx context.Context, req *entity.SetCategoryTaxClassRequest) error {
+	if err := s.checkTaxClass(ctx, req.TaxClassId); err != nil {
+		return err
+	}
+
+	err := s.repo.SetCategoryTaxClass(ctx, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Kategori tidak ditemukan"))
+	}
+
+	return err
+}
+
+// GetShopTaxSettings returns the defaults, category tax class and prices
+// including tax, for a shop without settings.
+func (s *taxService) GetShopTaxSettings(ctx context.Context, req *entity.GetShopTaxSettingsRequest) (*entity.ShopTaxSettings, error) {
+	if err := s.authorizeShop(ctx, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.getShopTaxSettings(ctx, req.ShopId)
+}
+
+func (s *taxService) SetShopTaxSettings(ctx context.Context, req *entity.SetShopTaxSettingsRequest) (*entity.ShopTaxSettings, error) {
+	if err := s.authorizeShop(ctx, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if req.TaxClassId != "" {
+		if err := s.checkTaxClass(ctx, req.TaxClassId); err != nil {
+			return nil, err
+		}
+	}
+
+	before, err := s.getShopTaxSettings(ctx, req.ShopId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetShopTaxSettings(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.getShopTaxSettings(ctx, req.ShopId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, &auditEntity.RecordRequest{
+		ActorId:  req.UserId,
+		Entity:   auditEntity.EntityShop,
+		EntityId: req.ShopId,
+		Action:   auditEntity.ActionUpdate,
+		Before:   before,
+		After:    resp,
+	})
+
+	return resp, nil
+}
+
+func (s *taxService) getShopTaxSettings(ctx context.Context, shopId string) (*entity.ShopTaxSettings, error) {
+	resp, err := s.repo.GetShopTaxSettings(ctx, shopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &entity.ShopTaxSettings{ShopId: shopId, PricesIncludeTax: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *taxService) checkTaxClass(ctx context.Context, id string) error {
+	_, err := s.repo.GetTaxClass(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("tax_class_id", "kelas pajak tidak ditemukan."))
+	}
+
+	return err
+}
+
+func (s *taxService) authorizeShop(ctx context.Context, shopId, userId string) error {
+	shop, err := s.repo.GetShopOwnership(ctx, shopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+	}
+	if err != nil {
+		return err
+	}
+
+	if shop.UserId != userId {
+		return errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke toko ini"))
+	}
+
+	return nil
+}
+
+func validateRate(rate string) error {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() < 0 || r.Cmp(big.NewRat(100, 1)) > 0 {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("rate", "rate harus antara 0 dan 100."))
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"errors"
+	"time"
+)
+
+const (
+	TypePercentage = "percentage"
+	TypeFixed      = "fixed"
+)
+
+var (
+	// ErrUsageLimitReached is returned by Redeem when the voucher has no
+	// uses left.
+	ErrUsageLimitReached = errors.New("voucher usage limit reached")
+	// ErrPerUserLimitReached is returned by Redeem when the user has used the
+	// voucher as often as allowed.
+	ErrPerUserLimitReached = errors.New("voucher per user limit reached")
+)
+
+// VoucherRequest holds the fields shared by create and update.
+type VoucherRequest struct {
+	Code            string      `json:"code" validate:"required,alphanum,min=3,max=50" db:"code"`
+	Name            string      `json:"name" validate:"required,min=3,max=255" db:"name"`
+	DiscountType    string      `json:"discount_type" validate:"required,oneof=percentage fixed" db:"discount_type"`
+	DiscountPercent int         `json:"discount_percent" validate:"required_if=DiscountType percentage,omitempty,min=1,max=100" db:"discount_percent"`
+	DiscountAmount  types.Money `json:"discount_amount" validate:"required_if=DiscountType fixed,omitempty,gt=0" db:"discount_amount"`
+	MaxDiscount     types.Money `json:"max_discount" validate:"omitempty,gt=0" db:"max_discount"`
+	MinSpend        types.Money `json:"min_spend" validate:"omitempty,gte=0" db:"min_spend"`
+	UsageLimit      int         `json:"usage_limit" validate:"omitempty,min=1" db:"usage_limit"`
+	PerUserLimit    int         `json:"per_user_limit" validate:"omitempty,min=1" db:"per_user_limit"`
+	StartsAt        time.Time   `json:"starts_at" validate:"required" db:"starts_at"`
+	EndsAt          time.Time   `json:"ends_at" validate:"required,gtfield=StartsAt" db:"ends_at"`
+
+	// ProductIds and CategoryIds restrict the voucher to those products or
+	// categories. Both empty means every product of the shop.
+	ProductIds  []string `json:"product_ids" validate:"omitempty,max=500,dive,uuid"`
+	CategoryIds []string `json:"category_ids" validate:"omitempty,max=100,dive,uuid"`
+}
+
+type CreateVoucherRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ShopId string `json:"shop_id" validate:"required,uuid" db:"shop_id"`
+	VoucherRequest
+}
+
+type CreateVoucherResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type UpdateVoucherRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid" db:"id"`
+	VoucherRequest
+}
+
+type UpdateVoucherResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type DeleteVoucherRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+}
+
+type GetVoucherRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	Id string `params:"id" validate:"uuid"`
+}
+
+type VoucherItem struct {
+	Id              string       `json:"id" db:"id"`
+	ShopId          string       `json:"shop_id" db:"shop_id"`
+	Code            string       `json:"code" db:"code"`
+	Name            string       `json:"name" db:"name"`
+	DiscountType    string       `json:"discount_type" db:"discount_type"`
+	DiscountPercent *int         `json:"discount_percent" db:"discount_percent"`
+	DiscountAmount  *types.Money `json:"discount_amount" db:"discount_amount"`
+	MaxDiscount     *types.Money `json:"max_discount" db:"max_discount"`
+	MinSpend        types.Money  `json:"min_spend" db:"min_spend"`
+	UsageLimit      *int         `json:"usage_limit" db:"usage_limit"`
+	PerUserLimit    *int         `json:"per_user_limit" db:"per_user_limit"`
+	UsedCount       int          `json:"used_count" db:"used_count"`
+	StartsAt        time.Time    `json:"starts_at" db:"starts_at"`
+	EndsAt          time.Time    `json:"ends_at" db:"ends_at"`
+	Currency        string       `json:"currency" db:"currency"`
+	ProductIds      []string     `json:"product_ids" db:"-"`
+	CategoryIds     []string     `json:"category_ids" db:"-"`
+}
+
+type GetVouchersRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ShopId   string `query:"shop_id" validate:"required,uuid"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *GetVouchersRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type GetVouchersResponse struct {
+	Items []VoucherItem `json:"items"`
+	Meta  types.Meta    `json:"meta"`
+}
+
+type CartItem struct {
+	ProductId string `json:"product_id" validate:"required,uuid"`
+	Quantity  int    `json:"quantity" validate:"required,min=1"`
+}
+
+// EvaluateVoucherRequest is sent by the checkout service with the cart of a
+// single shop.
+type EvaluateVoucherRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+
+	ShopId string     `json:"shop_id" validate:"required,uuid"`
+	Code   string     `json:"code" validate:"required"`
+	Items  []CartItem `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+type EvaluateVoucherResponse struct {
+	VoucherId          string      `json:"voucher_id"`
+	Code               string      `json:"code"`
+	EligibleProductIds []string    `json:"eligible_product_ids"`
+	EligibleSubtotal   types.Money `json:"eligible_subtotal"`
+	Discount           types.Money `json:"discount"`
+}
+
+// RedeemVoucherRequest records a use of the voucher for an order. The
+// voucher is evaluated again so the discount matches the current cart.
+type RedeemVoucherRequest struct {
+	EvaluateVoucherRequest
+
+	OrderId string `json:"order_id" validate:"required,max=100"`
+}
+
+// ShopOwnership is used to authorize changes to a shop's vouchers.
+type ShopOwnership struct {
+	Id     string `db:"id"`
+	UserId string `db:"user_id"`
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	productRepository "codebase-app/internal/module/product/repository"
+	"codebase-app/internal/module/voucher/entity"
+	"codebase-app/internal/module/voucher/ports"
+	"codebase-app/internal/module/voucher/repository"
+	"codebase-app/internal/module/voucher/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type voucherHandler struct {
+	service ports.VoucherService
+}
+
+func NewVoucherHandler() *voucherHandler {
+	var (
+		handler  = new(voucherHandler)
+		repo     = repository.NewVoucherRepository(adapter.Adapters.ShopeefunPostgres)
+		products = productRepository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		service  = service.NewVoucherService(repo, products)
+	)
+	handler.service = service
+
+	return handler
+}
+
+func (h *voucherHandler) Register(router fiber.Router) {
+	router.Post("/vouchers", middleware.UserIdHeader, h.CreateVoucher)
+	router.Get("/vouchers", middleware.UserIdHeader, h.GetVouchers)
+	router.Post("/vouchers/evaluate", middleware.UserIdHeader, h.EvaluateVoucher)
+	router.Post("/vouchers/redeem", middleware.UserIdHeader, h.RedeemVoucher)
+	router.Get("/vouchers/:id", middleware.UserIdHeader, h.GetVoucher)
+	router.Patch("/vouchers/:id", middleware.UserIdHeader, h.UpdateVoucher)
+	router.Delete("/vouchers/:id", middleware.UserIdHeader, h.DeleteVoucher)
+}
+
+func (h *voucherHandler) CreateVoucher(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateVoucherRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateVoucher - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateVoucher - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateVoucher(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, "Voucher berhasil dibuat"))
+}
+
+func (h *voucherHandler) GetVouchers(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetVouchersRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetVouchers - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetVouchers - Validate request query")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetVouchers(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *voucherHandler) GetVoucher(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetVoucherRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetVoucher - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetVoucher(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *voucherHandler) UpdateVoucher(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpdateVoucherRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateVoucher - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateVoucher - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateVoucher(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Voucher berhasil diupdate"))
+}
+
+func (h *voucherHandler) DeleteVoucher(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteVoucherRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteVoucher - Validate request params")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.service.DeleteVoucher(ctx, req); err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, "Voucher berhasil dihapus"))
+}
+
+func (h *voucherHandler) EvaluateVoucher(c *fiber.Ctx) error {
+	var (
+		req = new(entity.EvaluateVoucherRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::EvaluateVoucher - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::EvaluateVoucher - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.EvaluateVoucher(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Voucher dapat digunakan"))
+}
+
+func (h *voucherHandler) RedeemVoucher(c *fiber.Ctx) error {
+	var (
+		req = new(entity.RedeemVoucherRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::RedeemVoucher - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::RedeemVoucher - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.RedeemVoucher(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, "Voucher berhasil digunakan"))
+}
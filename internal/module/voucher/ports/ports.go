@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"codebase-app/internal/module/voucher/entity"
+	"codebase-app/pkg/types"
+	"context"
+)
+
+type VoucherRepository interface {
+	GetShopOwnership(ctx context.Context, shopId string) (*entity.ShopOwnership, error)
+	CountShopProducts(ctx context.Context, shopId string, productIds []string) (int, error)
+	CreateVoucher(ctx context.Context, req *entity.CreateVoucherRequest) (*entity.CreateVoucherResponse, error)
+	UpdateVoucher(ctx context.Context, req *entity.UpdateVoucherRequest) (*entity.UpdateVoucherResponse, error)
+	DeleteVoucher(ctx context.Context, req *entity.DeleteVoucherRequest) error
+	GetVoucher(ctx context.Context, id string) (*entity.VoucherItem, error)
+	GetVoucherByCode(ctx context.Context, shopId, code string) (*entity.VoucherItem, error)
+	GetVouchers(ctx context.Context, req *entity.GetVouchersRequest) (*entity.GetVouchersResponse, error)
+	CountUserUsages(ctx context.Context, voucherId, userId string) (int, error)
+	Redeem(ctx context.Context, voucherId, userId, orderId string, discount types.Money) error
+}
+
+type VoucherService interface {
+	CreateVoucher(ctx context.Context, req *entity.CreateVoucherRequest) (*entity.CreateVoucherResponse, error)
+	UpdateVoucher(ctx context.Context, req *entity.UpdateVoucherRequest) (*entity.UpdateVoucherResponse, error)
+	DeleteVoucher(ctx context.Context, req *entity.DeleteVoucherRequest) error
+	GetVoucher(ctx context.Context, req *entity.GetVoucherRequest) (*entity.VoucherItem, error)
+	GetVouchers(ctx context.Context, req *entity.GetVouchersRequest) (*entity.GetVouchersResponse, error)
+	EvaluateVoucher(ctx context.Context, req *entity.EvaluateVoucherRequest) (*entity.EvaluateVoucherResponse, error)
+	RedeemVoucher(ctx context.Context, req *entity.RedeemVoucherRequest) (*entity.EvaluateVoucherResponse, error)
+}
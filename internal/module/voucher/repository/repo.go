@@ -0,0 +1,443 @@
+package repository
+
+import (
+	"codebase-app/internal/module/voucher/entity"
+	"codebase-app/internal/module/voucher/ports"
+	"codebase-app/pkg/types"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.VoucherRepository = &voucherRepository{}
+
+// voucherColumns selects a voucher row aliased as v in the shape voucherDao
+// scans, including its product and category restrictions.
+const voucherColumns = `
+	v.id,
+	v.shop_id,
+	v.code,
+	v.name,
+	v.discount_type,
+	v.discount_percent,
+	v.discount_amount,
+	v.max_discount,
+	v.min_spend,
+	v.usage_limit,
+	v.per_user_limit,
+	v.used_count,
+	v.starts_at,
+	v.ends_at,
+	(SELECT s.currency FROM shops s WHERE s.id = v.shop_id) as currency,
+	ARRAY(SELECT vp.product_id::text FROM voucher_products vp WHERE vp.voucher_id = v.id ORDER BY vp.product_id) as product_ids,
+	ARRAY(SELECT vc.category_id::text FROM voucher_categories vc WHERE vc.voucher_id = v.id ORDER BY vc.category_id) as category_ids
+`
+
+type voucherDao struct {
+	entity.VoucherItem
+	ProductIds  pq.StringArray `db:"product_ids"`
+	CategoryIds pq.StringArray `db:"category_ids"`
+}
+
+// item labels the amounts, scanned without a currency, with the shop
+// currency so they can be compared with the product prices.
+func (d voucherDao) item() (*entity.VoucherItem, error) {
+	var (
+		item = d.VoucherItem
+		err  error
+	)
+	item.ProductIds = []string(d.ProductIds)
+	item.CategoryIds = []string(d.CategoryIds)
+
+	if item.MinSpend, err = item.MinSpend.WithCurrency(item.Currency); err != nil {
+		return nil, err
+	}
+	for _, amount := range []*types.Money{item.DiscountAmount, item.MaxDiscount} {
+		if amount == nil {
+			continue
+		}
+		if *amount, err = amount.WithCurrency(item.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	return &item, nil
+}
+
+type voucherRepository struct {
+	db *sqlx.DB
+}
+
+func NewVoucherRepository(db *sqlx.DB) *voucherRepository {
+	return &voucherRepository{
+		db: db,
+	}
+}
+
+func (r *voucherRepository) GetShopOwnership(ctx context.Context, shopId string) (*entity.ShopOwnership, error) {
+	var resp = new(entity.ShopOwnership)
+
+	query := `SELECT id, user_id FROM shops WHERE id = ? AND deleted_at IS NULL`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), shopId).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetShopOwnership - Failed to get shop ownership")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CountShopProducts returns how many of productIds belong to the shop.
+func (r *voucherRepository) CountShopProducts(ctx context.Context, shopId string, productIds []string) (int, error) {
+	var count int
+
+	query := `SELECT COUNT(id) FROM product WHERE shop_id = ? AND id = ANY(?::uuid[]) AND deleted_at IS NULL`
+
+	err := r.db.GetContext(ctx, &count, r.db.Rebind(query), shopId, pq.Array(productIds))
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::CountShopProducts - Failed to count shop products")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *voucherRepository) CreateVoucher(ctx context.Context, req *entity.CreateVoucherRequest) (*entity.CreateVoucherResponse, error) {
+	var resp = new(entity.CreateVoucherResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::CreateVoucher - Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::CreateVoucher - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	query := `
+		INSERT INTO vouchers (
+			shop_id, code, name, discount_type, discount_percent, discount_amount, max_discount,
+			min_spend, usage_limit, per_user_limit, starts_at, ends_at
+		)
+		VALUES (
+			?, ?, ?, ?, NULLIF(?, 0), NULLIF(?::numeric, 0), NULLIF(?::numeric, 0),
+			?, NULLIF(?, 0), NULLIF(?, 0), ?, ?
+		)
+		RETURNING id
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		req.ShopId,
+		req.Code,
+		req.Name,
+		req.DiscountType,
+		req.DiscountPercent,
+		req.DiscountAmount,
+		req.MaxDiscount,
+		req.MinSpend,
+		req.UsageLimit,
+		req.PerUserLimit,
+		req.StartsAt,
+		req.EndsAt).Scan(&resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateVoucher - Failed to create voucher")
+		return nil, err
+	}
+
+	if err = r.setRestrictions(ctx, tx, resp.Id, &req.VoucherRequest); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateVoucher - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *voucherRepository) UpdateVoucher(ctx context.Context, req *entity.UpdateVoucherRequest) (*entity.UpdateVoucherResponse, error) {
+	var resp = new(entity.UpdateVoucherResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::UpdateVoucher - Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::UpdateVoucher - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	query := `
+		UPDATE vouchers
+		SET
+			code = ?,
+			name = ?,
+			discount_type = ?,
+			discount_percent = NULLIF(?, 0),
+			discount_amount = NULLIF(?::numeric, 0),
+			max_discount = NULLIF(?::numeric, 0),
+			min_spend = ?,
+			usage_limit = NULLIF(?, 0),
+			per_user_limit = NULLIF(?, 0),
+			starts_at = ?,
+			ends_at = ?,
+			updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	err = tx.QueryRowxContext(ctx, tx.Rebind(query),
+		req.Code,
+		req.Name,
+		req.DiscountType,
+		req.DiscountPercent,
+		req.DiscountAmount,
+		req.MaxDiscount,
+		req.MinSpend,
+		req.UsageLimit,
+		req.PerUserLimit,
+		req.StartsAt,
+		req.EndsAt,
+		req.Id).Scan(&resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateVoucher - Failed to update voucher")
+		return nil, err
+	}
+
+	if err = r.setRestrictions(ctx, tx, resp.Id, &req.VoucherRequest); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateVoucher - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// setRestrictions replaces the product and category restrictions of a
+// voucher.
+func (r *voucherRepository) setRestrictions(ctx context.Context, tx *sqlx.Tx, voucherId string, req *entity.VoucherRequest) error {
+	queries := []string{
+		`DELETE FROM voucher_products WHERE voucher_id = ?`,
+		`DELETE FROM voucher_categories WHERE voucher_id = ?`,
+	}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), voucherId); err != nil {
+			log.Error().Err(err).Str("voucher_id", voucherId).Msg("repository::setRestrictions - Failed to clear restrictions")
+			return err
+		}
+	}
+
+	if len(req.ProductIds) > 0 {
+		query := `
+			INSERT INTO voucher_products (voucher_id, product_id)
+			SELECT DISTINCT ?::uuid, UNNEST(?::uuid[])
+		`
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), voucherId, pq.Array(req.ProductIds)); err != nil {
+			log.Error().Err(err).Str("voucher_id", voucherId).Msg("repository::setRestrictions - Failed to set product restrictions")
+			return err
+		}
+	}
+
+	if len(req.CategoryIds) > 0 {
+		query := `
+			INSERT INTO voucher_categories (voucher_id, category_id)
+			SELECT DISTINCT ?::uuid, UNNEST(?::uuid[])
+		`
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), voucherId, pq.Array(req.CategoryIds)); err != nil {
+			log.Error().Err(err).Str("voucher_id", voucherId).Msg("repository::setRestrictions - Failed to set category restrictions")
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *voucherRepository) DeleteVoucher(ctx context.Context, req *entity.DeleteVoucherRequest) error {
+	query := `UPDATE vouchers SET deleted_at = NOW(), updated_at = NOW() WHERE id = ? AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteVoucher - Failed to delete voucher")
+		return err
+	}
+
+	return nil
+}
+
+func (r *voucherRepository) GetVoucher(ctx context.Context, id string) (*entity.VoucherItem, error) {
+	var data voucherDao
+
+	query := `SELECT ` + voucherColumns + ` FROM vouchers v WHERE v.id = ? AND v.deleted_at IS NULL`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), id).StructScan(&data)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::GetVoucher - Failed to get voucher")
+		return nil, err
+	}
+
+	return data.item()
+}
+
+func (r *voucherRepository) GetVoucherByCode(ctx context.Context, shopId, code string) (*entity.VoucherItem, error) {
+	var data voucherDao
+
+	query := `
+		SELECT ` + voucherColumns + `
+		FROM vouchers v
+		WHERE v.shop_id = ? AND UPPER(v.code) = UPPER(?) AND v.deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), shopId, code).StructScan(&data)
+	if err != nil {
+		log.Warn().Err(err).Str("shop_id", shopId).Str("code", code).Msg("repository::GetVoucherByCode - Failed to get voucher")
+		return nil, err
+	}
+
+	return data.item()
+}
+
+func (r *voucherRepository) GetVouchers(ctx context.Context, req *entity.GetVouchersRequest) (*entity.GetVouchersResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		voucherDao
+	}
+
+	var (
+		resp = new(entity.GetVouchersResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.VoucherItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(v.id) OVER() as total_data,
+			` + voucherColumns + `
+		FROM vouchers v
+		WHERE v.shop_id = ? AND v.deleted_at IS NULL
+		ORDER BY v.created_at DESC, v.id
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), req.ShopId, req.Paginate, (req.Page-1)*req.Paginate)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetVouchers - Failed to get vouchers")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		item, err := d.voucherDao.item()
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::GetVouchers - Failed to set currency")
+			return nil, err
+		}
+		resp.Items = append(resp.Items, *item)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *voucherRepository) CountUserUsages(ctx context.Context, voucherId, userId string) (int, error) {
+	var count int
+
+	query := `SELECT COUNT(id) FROM voucher_usages WHERE voucher_id = ? AND user_id = ?`
+
+	err := r.db.GetContext(ctx, &count, r.db.Rebind(query), voucherId, userId)
+	if err != nil {
+		log.Error().Err(err).Str("voucher_id", voucherId).Msg("repository::CountUserUsages - Failed to count usages")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Redeem records a use of the voucher. The voucher row is locked so the usage
+// cap and the per user limit hold under concurrent checkouts.
+func (r *voucherRepository) Redeem(ctx context.Context, voucherId, userId, orderId string, discount types.Money) error {
+	type dao struct {
+		UsageLimit   *int `db:"usage_limit"`
+		PerUserLimit *int `db:"per_user_limit"`
+		UsedCount    int  `db:"used_count"`
+	}
+
+	var (
+		voucher dao
+		used    int
+	)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::Redeem - Failed to begin transaction")
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				log.Error().Err(errRollback).Msg("repository::Redeem - Failed to rollback transaction")
+			}
+		}
+	}()
+
+	query := `SELECT usage_limit, per_user_limit, used_count FROM vouchers WHERE id = ? FOR UPDATE`
+	if err = tx.GetContext(ctx, &voucher, tx.Rebind(query), voucherId); err != nil {
+		log.Error().Err(err).Str("voucher_id", voucherId).Msg("repository::Redeem - Failed to lock voucher")
+		return err
+	}
+
+	if voucher.UsageLimit != nil && voucher.UsedCount >= *voucher.UsageLimit {
+		err = entity.ErrUsageLimitReached
+		return err
+	}
+
+	if voucher.PerUserLimit != nil {
+		query = `SELECT COUNT(id) FROM voucher_usages WHERE voucher_id = ? AND user_id = ?`
+		if err = tx.GetContext(ctx, &used, tx.Rebind(query), voucherId, userId); err != nil {
+			log.Error().Err(err).Str("voucher_id", voucherId).Msg("repository::Redeem - Failed to count usages")
+			return err
+		}
+
+		if used >= *voucher.PerUserLimit {
+			err = entity.ErrPerUserLimitReached
+			return err
+		}
+	}
+
+	query = `INSERT INTO voucher_usages (voucher_id, user_id, order_id, discount) VALUES (?, ?, ?, ?)`
+	if _, err = tx.ExecContext(ctx, tx.Rebind(query), voucherId, userId, orderId, discount); err != nil {
+		log.Error().Err(err).Str("voucher_id", voucherId).Str("order_id", orderId).Msg("repository::Redeem - Failed to record usage")
+		return err
+	}
+
+	query = `UPDATE vouchers SET used_count = used_count + 1, updated_at = NOW() WHERE id = ?`
+	if _, err = tx.ExecContext(ctx, tx.Rebind(query), voucherId); err != nil {
+		log.Error().Err(err).Str("voucher_id", voucherId).Msg("repository::Redeem - Failed to increment usage")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Str("voucher_id", voucherId).Msg("repository::Redeem - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
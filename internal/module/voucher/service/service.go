@@ -0,0 +1,329 @@
+package service
+
+import (
+	productEntity "codebase-app/internal/module/product/entity"
+	productPorts "codebase-app/internal/module/product/ports"
+	"codebase-app/internal/module/voucher/entity"
+	"codebase-app/internal/module/voucher/ports"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/types"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var _ ports.VoucherService = &voucherService{}
+
+type voucherService struct {
+	repo     ports.VoucherRepository
+	products productPorts.ProductRepository
+}
+
+// NewVoucherService takes the product repository to price the cart with the
+// same effective price the product listing shows.
+func NewVoucherService(repo ports.VoucherRepository, products productPorts.ProductRepository) *voucherService {
+	return &voucherService{
+		repo:     repo,
+		products: products,
+	}
+}
+
+func (s *voucherService) CreateVoucher(ctx context.Context, req *entity.CreateVoucherRequest) (*entity.CreateVoucherResponse, error) {
+	if err := s.authorizeShop(ctx, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := s.prepare(ctx, req.ShopId, &req.VoucherRequest); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.CreateVoucher(ctx, req)
+	if err != nil {
+		return nil, duplicateCode(err)
+	}
+
+	return resp, nil
+}
+
+func (s *voucherService) UpdateVoucher(ctx context.Context, req *entity.UpdateVoucherRequest) (*entity.UpdateVoucherResponse, error) {
+	voucher, err := s.authorizeVoucher(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.prepare(ctx, voucher.ShopId, &req.VoucherRequest); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.UpdateVoucher(ctx, req)
+	if err != nil {
+		return nil, duplicateCode(err)
+	}
+
+	return resp, nil
+}
+
+func (s *voucherService) DeleteVoucher(ctx context.Context, req *entity.DeleteVoucherRequest) error {
+	if _, err := s.authorizeVoucher(ctx, req.Id, req.UserId); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteVoucher(ctx, req)
+}
+
+func (s *voucherService) GetVoucher(ctx context.Context, req *entity.GetVoucherRequest) (*entity.VoucherItem, error) {
+	return s.authorizeVoucher(ctx, req.Id, req.UserId)
+}
+
+func (s *voucherService) GetVouchers(ctx context.Context, req *entity.GetVouchersRequest) (*entity.GetVouchersResponse, error) {
+	if err := s.authorizeShop(ctx, req.ShopId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetVouchers(ctx, req)
+}
+
+// EvaluateVoucher checks the voucher against the cart and returns the
+// discount. When the voucher does not apply the error says why, in the same
+// words the buyer sees.
+func (s *voucherService) EvaluateVoucher(ctx context.Context, req *entity.EvaluateVoucherRequest) (*entity.EvaluateVoucherResponse, error) {
+	resp, _, err := s.evaluate(ctx, req)
+	return resp, err
+}
+
+func (s *voucherService) RedeemVoucher(ctx context.Context, req *entity.RedeemVoucherRequest) (*entity.EvaluateVoucherResponse, error) {
+	resp, voucher, err := s.evaluate(ctx, &req.EvaluateVoucherRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.Redeem(ctx, voucher.Id, req.UserId, req.OrderId, resp.Discount)
+	switch {
+	case errors.Is(err, entity.ErrUsageLimitReached):
+		return nil, notApplicable("Kuota voucher sudah habis")
+	case errors.Is(err, entity.ErrPerUserLimitReached):
+		return nil, notApplicable("Anda sudah mencapai batas penggunaan voucher ini")
+	case err != nil:
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code.Name() == "unique_violation" {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("order_id", "voucher sudah digunakan untuk pesanan ini."))
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *voucherService) evaluate(ctx context.Context, req *entity.EvaluateVoucherRequest) (*entity.EvaluateVoucherResponse, *entity.VoucherItem, error) {
+	voucher, err := s.repo.GetVoucherByCode(ctx, req.ShopId, req.Code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Voucher tidak ditemukan"), errmsg.WithErrors("code", "voucher tidak ditemukan."))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if now.Before(voucher.StartsAt) {
+		return nil, nil, notApplicable("Voucher belum dapat digunakan")
+	}
+	if !now.Before(voucher.EndsAt) {
+		return nil, nil, notApplicable("Voucher sudah tidak berlaku")
+	}
+
+	if voucher.UsageLimit != nil && voucher.UsedCount >= *voucher.UsageLimit {
+		return nil, nil, notApplicable("Kuota voucher sudah habis")
+	}
+
+	if voucher.PerUserLimit != nil {
+		used, err := s.repo.CountUserUsages(ctx, voucher.Id, req.UserId)
+		if err != nil {
+			return nil, nil, err
+		}
+		if used >= *voucher.PerUserLimit {
+			return nil, nil, notApplicable("Anda sudah mencapai batas penggunaan voucher ini")
+		}
+	}
+
+	var (
+		quantities = make(map[string]int, len(req.Items))
+		order      = make([]string, 0, len(req.Items))
+		resp       = &entity.EvaluateVoucherResponse{
+			VoucherId:          voucher.Id,
+			Code:               voucher.Code,
+			EligibleProductIds: make([]string, 0, len(req.Items)),
+			EligibleSubtotal:   types.Money{Currency: voucher.MinSpend.Currency},
+		}
+	)
+
+	for _, item := range req.Items {
+		if _, ok := quantities[item.ProductId]; !ok {
+			order = append(order, item.ProductId)
+		}
+		quantities[item.ProductId] += item.Quantity
+	}
+
+	for _, id := range order {
+		product, err := s.products.GetDetailProduct(ctx, &productEntity.GetProductDetailRequest{Id: id})
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && (product.Shop.Id != voucher.ShopId || product.Status != productEntity.ProductStatusActive)) {
+			return nil, nil, errmsg.NewCustomErrors(422, errmsg.WithMessage("Produk tidak tersedia di toko ini"), errmsg.WithErrors("items", fmt.Sprintf("produk %s tidak tersedia di toko ini.", id)))
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !eligible(voucher, product.Id, product.Category.Id) {
+			continue
+		}
+
+		resp.EligibleSubtotal, err = resp.EligibleSubtotal.Add(product.EffectivePrice.Mul(int64(quantities[id])))
+		if errors.Is(err, types.ErrCurrencyMismatch) {
+			return nil, nil, currencyMismatch(voucher.Currency, product.Currency)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		resp.EligibleProductIds = append(resp.EligibleProductIds, id)
+	}
+
+	if len(resp.EligibleProductIds) == 0 {
+		return nil, nil, notApplicable("Voucher tidak berlaku untuk produk yang dipilih")
+	}
+
+	cmp, err := resp.EligibleSubtotal.Cmp(voucher.MinSpend)
+	if err != nil {
+		return nil, nil, currencyMismatch(voucher.MinSpend.Currency, resp.EligibleSubtotal.Currency)
+	}
+	if cmp < 0 {
+		return nil, nil, notApplicable(fmt.Sprintf("Minimal belanja %s %s untuk menggunakan voucher ini", voucher.MinSpend.Currency, voucher.MinSpend))
+	}
+
+	resp.Discount = discount(voucher, resp.EligibleSubtotal)
+
+	return resp, voucher, nil
+}
+
+// eligible reports whether the voucher restrictions allow the product. A
+// voucher without restrictions applies to every product of its shop.
+func eligible(voucher *entity.VoucherItem, productId, categoryId string) bool {
+	if len(voucher.ProductIds) == 0 && len(voucher.CategoryIds) == 0 {
+		return true
+	}
+
+	return slices.Contains(voucher.ProductIds, productId) || slices.Contains(voucher.CategoryIds, categoryId)
+}
+
+// discount never exceeds the eligible subtotal or, for percentage vouchers,
+// the configured maximum.
+func discount(voucher *entity.VoucherItem, subtotal types.Money) types.Money {
+	var d types.Money
+
+	switch voucher.DiscountType {
+	case entity.TypePercentage:
+		d = subtotal.MulRat(big.NewRat(int64(*voucher.DiscountPercent), 100))
+		if voucher.MaxDiscount != nil && d.Amount > voucher.MaxDiscount.Amount {
+			d.Amount = voucher.MaxDiscount.Amount
+		}
+	case entity.TypeFixed:
+		d = *voucher.DiscountAmount
+		if d.Amount > subtotal.Amount {
+			d.Amount = subtotal.Amount
+		}
+	}
+
+	return d
+}
+
+// prepare checks the restrictions belong to the shop and clears the fields
+// that do not apply to the discount type.
+func (s *voucherService) prepare(ctx context.Context, shopId string, req *entity.VoucherRequest) error {
+	switch req.DiscountType {
+	case entity.TypePercentage:
+		req.DiscountAmount = types.Money{}
+	case entity.TypeFixed:
+		req.DiscountPercent = 0
+		req.MaxDiscount = types.Money{}
+	}
+
+	slices.Sort(req.ProductIds)
+	req.ProductIds = slices.Compact(req.ProductIds)
+	slices.Sort(req.CategoryIds)
+	req.CategoryIds = slices.Compact(req.CategoryIds)
+
+	if len(req.ProductIds) == 0 {
+		return nil
+	}
+
+	count, err := s.repo.CountShopProducts(ctx, shopId, req.ProductIds)
+	if err != nil {
+		return err
+	}
+	if count != len(req.ProductIds) {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("product_ids", "semua produk harus milik toko ini."))
+	}
+
+	return nil
+}
+
+func (s *voucherService) authorizeShop(ctx context.Context, shopId, userId string) error {
+	shop, err := s.repo.GetShopOwnership(ctx, shopId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+	}
+	if err != nil {
+		return err
+	}
+
+	if shop.UserId != userId {
+		return errmsg.NewCustomErrors(403, errmsg.WithMessage("Anda tidak memiliki akses ke toko ini"))
+	}
+
+	return nil
+}
+
+func (s *voucherService) authorizeVoucher(ctx context.Context, id, userId string) (*entity.VoucherItem, error) {
+	voucher, err := s.repo.GetVoucher(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Voucher tidak ditemukan"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeShop(ctx, voucher.ShopId, userId); err != nil {
+		return nil, err
+	}
+
+	return voucher, nil
+}
+
+// notApplicable is the error returned when a voucher exists but cannot be
+// used for the cart.
+func notApplicable(reason string) error {
+	return errmsg.NewCustomErrors(422, errmsg.WithMessage(reason), errmsg.WithErrors("code", reason+"."))
+}
+
+// currencyMismatch is returned when the voucher amounts and the cart are in
+// different currencies, ex: the shop currency changed after the voucher was
+// created. It is not a reason the buyer can act on, so it is not
+// notApplicable.
+func currencyMismatch(voucherCurrency, cartCurrency string) error {
+	return errmsg.NewCustomErrors(409,
+		errmsg.WithMessage("Mata uang voucher tidak sesuai dengan mata uang produk"),
+		errmsg.WithErrors("code", fmt.Sprintf("voucher dalam %s, produk dalam %s.", voucherCurrency, cartCurrency)))
+}
+
+func duplicateCode(err error) error {
+	var errPq *pq.Error
+	if errors.As(err, &errPq) && errPq.Code.Name() == "unique_violation" {
+		return errmsg.NewCustomErrors(409, errmsg.WithErrors("code", "kode voucher sudah digunakan di toko ini."))
+	}
+
+	return err
+}
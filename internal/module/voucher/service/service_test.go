@@ -0,0 +1,274 @@
+package service
+
+import (
+	productEntity "codebase-app/internal/module/product/entity"
+	productPorts "codebase-app/internal/module/product/ports"
+	"codebase-app/internal/module/voucher/entity"
+	"codebase-app/internal/module/voucher/ports"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/types"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func idr(amount int64) types.Money { return types.NewMoney(amount*100, "IDR") }
+
+func ptr[T any](v T) *T { return &v }
+
+func TestDiscount(t *testing.T) {
+	tests := []struct {
+		name     string
+		voucher  entity.VoucherItem
+		subtotal types.Money
+		want     types.Money
+	}{
+		{
+			name:     "percentage",
+			voucher:  entity.VoucherItem{DiscountType: entity.TypePercentage, DiscountPercent: ptr(10)},
+			subtotal: idr(150000),
+			want:     idr(15000),
+		},
+		{
+			name:     "percentage rounds half away from zero",
+			voucher:  entity.VoucherItem{DiscountType: entity.TypePercentage, DiscountPercent: ptr(15)},
+			subtotal: types.NewMoney(333, "IDR"),
+			want:     types.NewMoney(50, "IDR"),
+		},
+		{
+			name:     "percentage capped by max discount",
+			voucher:  entity.VoucherItem{DiscountType: entity.TypePercentage, DiscountPercent: ptr(50), MaxDiscount: ptr(idr(20000))},
+			subtotal: idr(150000),
+			want:     idr(20000),
+		},
+		{
+			name:     "percentage under max discount",
+			voucher:  entity.VoucherItem{DiscountType: entity.TypePercentage, DiscountPercent: ptr(10), MaxDiscount: ptr(idr(20000))},
+			subtotal: idr(150000),
+			want:     idr(15000),
+		},
+		{
+			name:     "fixed",
+			voucher:  entity.VoucherItem{DiscountType: entity.TypeFixed, DiscountAmount: ptr(idr(20000))},
+			subtotal: idr(150000),
+			want:     idr(20000),
+		},
+		{
+			name:     "fixed clamped to the subtotal",
+			voucher:  entity.VoucherItem{DiscountType: entity.TypeFixed, DiscountAmount: ptr(idr(75000))},
+			subtotal: idr(50000),
+			want:     idr(50000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, discount(&tt.voucher, tt.subtotal))
+		})
+	}
+}
+
+func TestEligible(t *testing.T) {
+	tests := []struct {
+		name       string
+		voucher    entity.VoucherItem
+		productId  string
+		categoryId string
+		want       bool
+	}{
+		{name: "no restrictions", productId: "p1", categoryId: "c1", want: true},
+		{name: "listed product", voucher: entity.VoucherItem{ProductIds: []string{"p1"}}, productId: "p1", categoryId: "c1", want: true},
+		{name: "listed category", voucher: entity.VoucherItem{CategoryIds: []string{"c1"}}, productId: "p1", categoryId: "c1", want: true},
+		{name: "either restriction", voucher: entity.VoucherItem{ProductIds: []string{"p2"}, CategoryIds: []string{"c1"}}, productId: "p1", categoryId: "c1", want: true},
+		{name: "other product", voucher: entity.VoucherItem{ProductIds: []string{"p2"}}, productId: "p1", categoryId: "c1", want: false},
+		{name: "other category", voucher: entity.VoucherItem{CategoryIds: []string{"c2"}}, productId: "p1", categoryId: "c1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, eligible(&tt.voucher, tt.productId, tt.categoryId))
+		})
+	}
+}
+
+type fakeVoucherRepository struct {
+	ports.VoucherRepository
+	voucher *entity.VoucherItem
+	used    int
+}
+
+func (f *fakeVoucherRepository) GetVoucherByCode(ctx context.Context, shopId, code string) (*entity.VoucherItem, error) {
+	if f.voucher == nil || f.voucher.ShopId != shopId || f.voucher.Code != code {
+		return nil, sql.ErrNoRows
+	}
+	v := *f.voucher
+	return &v, nil
+}
+
+func (f *fakeVoucherRepository) CountUserUsages(ctx context.Context, voucherId, userId string) (int, error) {
+	return f.used, nil
+}
+
+type fakeProductRepository struct {
+	productPorts.ProductRepository
+	products map[string]*productEntity.GetProductDetailResponse
+}
+
+func (f *fakeProductRepository) GetDetailProduct(ctx context.Context, req *productEntity.GetProductDetailRequest) (*productEntity.GetProductDetailResponse, error) {
+	p, ok := f.products[req.Id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+func TestEvaluateVoucher(t *testing.T) {
+	var (
+		now      = time.Now()
+		products = map[string]*productEntity.GetProductDetailResponse{
+			"p1": {Id: "p1", EffectivePrice: idr(100000), Currency: "IDR", Status: productEntity.ProductStatusActive, Category: productEntity.CategoryItem{Id: "c1"}, Shop: productEntity.ShopItem{Id: "s1"}},
+			"p2": {Id: "p2", EffectivePrice: idr(30000), Currency: "IDR", Status: productEntity.ProductStatusActive, Category: productEntity.CategoryItem{Id: "c2"}, Shop: productEntity.ShopItem{Id: "s1"}},
+			"p3": {Id: "p3", EffectivePrice: idr(30000), Currency: "IDR", Status: productEntity.ProductStatusActive, Category: productEntity.CategoryItem{Id: "c1"}, Shop: productEntity.ShopItem{Id: "s2"}},
+		}
+		base = entity.VoucherItem{
+			Id:              "v1",
+			ShopId:          "s1",
+			Code:            "HEMAT",
+			DiscountType:    entity.TypePercentage,
+			DiscountPercent: ptr(10),
+			MinSpend:        idr(0),
+			StartsAt:        now.Add(-time.Hour),
+			EndsAt:          now.Add(time.Hour),
+			Currency:        "IDR",
+		}
+		cart = []entity.CartItem{{ProductId: "p1", Quantity: 1}, {ProductId: "p2", Quantity: 2}}
+	)
+
+	with := func(change func(v *entity.VoucherItem)) *entity.VoucherItem {
+		v := base
+		change(&v)
+		return &v
+	}
+
+	tests := []struct {
+		name     string
+		voucher  *entity.VoucherItem
+		used     int
+		items    []entity.CartItem
+		code     int
+		msg      string
+		subtotal types.Money
+		discount types.Money
+	}{
+		{
+			name:     "whole cart",
+			voucher:  &base,
+			subtotal: idr(160000),
+			discount: idr(16000),
+		},
+		{
+			name:     "category restriction",
+			voucher:  with(func(v *entity.VoucherItem) { v.CategoryIds = []string{"c2"} }),
+			subtotal: idr(60000),
+			discount: idr(6000),
+		},
+		{
+			name:    "no eligible product",
+			voucher: with(func(v *entity.VoucherItem) { v.ProductIds = []string{"p9"} }),
+			code:    422,
+			msg:     "Voucher tidak berlaku untuk produk yang dipilih",
+		},
+		{
+			name:     "min spend met",
+			voucher:  with(func(v *entity.VoucherItem) { v.MinSpend = idr(160000) }),
+			subtotal: idr(160000),
+			discount: idr(16000),
+		},
+		{
+			name:    "min spend not met",
+			voucher: with(func(v *entity.VoucherItem) { v.MinSpend = idr(200000) }),
+			code:    422,
+			msg:     "Minimal belanja IDR 200000.00 untuk menggunakan voucher ini",
+		},
+		{
+			name:    "not started",
+			voucher: with(func(v *entity.VoucherItem) { v.StartsAt = now.Add(time.Minute) }),
+			code:    422,
+			msg:     "Voucher belum dapat digunakan",
+		},
+		{
+			name:    "ended",
+			voucher: with(func(v *entity.VoucherItem) { v.EndsAt = now.Add(-time.Minute) }),
+			code:    422,
+			msg:     "Voucher sudah tidak berlaku",
+		},
+		{
+			name:    "usage limit reached",
+			voucher: with(func(v *entity.VoucherItem) { v.UsageLimit = ptr(5); v.UsedCount = 5 }),
+			code:    422,
+			msg:     "Kuota voucher sudah habis",
+		},
+		{
+			name:    "per user limit reached",
+			voucher: with(func(v *entity.VoucherItem) { v.PerUserLimit = ptr(1) }),
+			used:    1,
+			code:    422,
+			msg:     "Anda sudah mencapai batas penggunaan voucher ini",
+		},
+		{
+			name:    "product of another shop",
+			voucher: &base,
+			items:   []entity.CartItem{{ProductId: "p3", Quantity: 1}},
+			code:    422,
+			msg:     "Produk tidak tersedia di toko ini",
+		},
+		{
+			name:    "currency mismatch",
+			voucher: with(func(v *entity.VoucherItem) { v.MinSpend = types.NewMoney(1000, "USD"); v.Currency = "USD" }),
+			code:    409,
+			msg:     "Mata uang voucher tidak sesuai dengan mata uang produk",
+		},
+		{
+			name:    "unknown code",
+			voucher: nil,
+			code:    404,
+			msg:     "Voucher tidak ditemukan",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				s     = NewVoucherService(&fakeVoucherRepository{voucher: tt.voucher, used: tt.used}, &fakeProductRepository{products: products})
+				items = tt.items
+			)
+			if items == nil {
+				items = cart
+			}
+
+			resp, err := s.EvaluateVoucher(context.Background(), &entity.EvaluateVoucherRequest{
+				UserId: "u1",
+				ShopId: "s1",
+				Code:   "HEMAT",
+				Items:  items,
+			})
+
+			if tt.code != 0 {
+				var customErr *errmsg.CustomError
+				if assert.ErrorAs(t, err, &customErr) {
+					assert.Equal(t, tt.code, customErr.Code)
+					assert.Equal(t, tt.msg, customErr.Msg)
+				}
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.subtotal, resp.EligibleSubtotal)
+				assert.Equal(t, tt.discount, resp.Discount)
+			}
+		})
+	}
+}
@@ -1,8 +1,21 @@
 package route
 
 import (
+	handlerAudit "codebase-app/internal/module/audit/handler/rest"
+	handlerCampaign "codebase-app/internal/module/campaign/handler/rest"
+	handlerCollection "codebase-app/internal/module/collection/handler/rest"
+	handlerExchangeRate "codebase-app/internal/module/exchange_rate/handler/rest"
+	handlerModeration "codebase-app/internal/module/moderation/handler/rest"
+	handlerNotification "codebase-app/internal/module/notification/handler/rest"
 	handlerShop "codebase-app/internal/module/shop/handler/rest"
+	handlerSitemap "codebase-app/internal/module/sitemap/handler/rest"
+	handlerTag "codebase-app/internal/module/tag/handler/rest"
+	handlerTax "codebase-app/internal/module/tax/handler/rest"
 	handlerProduct "codebase-app/internal/module/product/handler/rest"
+	handlerProductImport "codebase-app/internal/module/product_import/handler/rest"
+	handlerQuestion "codebase-app/internal/module/question/handler/rest"
+	handlerReview "codebase-app/internal/module/review/handler/rest"
+	handlerVoucher "codebase-app/internal/module/voucher/handler/rest"
 	"codebase-app/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,6 +28,19 @@ func SetupRoutes(app *fiber.App) {
 	)
 	handlerShop.NewShopHandler().Register(api)
 	handlerProduct.NewProductHandler().Register(api)
+	handlerProductImport.NewProductImportHandler().Register(api)
+	handlerAudit.NewAuditHandler().Register(api)
+	handlerCampaign.NewCampaignHandler().Register(api)
+	handlerVoucher.NewVoucherHandler().Register(api)
+	handlerExchangeRate.NewExchangeRateHandler().Register(api)
+	handlerTax.NewTaxHandler().Register(api)
+	handlerTag.NewTagHandler().Register(api)
+	handlerCollection.NewCollectionHandler().Register(api)
+	handlerReview.NewReviewHandler().Register(api)
+	handlerQuestion.NewQuestionHandler().Register(api)
+	handlerNotification.NewNotificationHandler().Register(api)
+	handlerSitemap.NewSitemapHandler().Register(api)
+	handlerModeration.NewModerationHandler().Register(api)
 
 	// fallback route
 	app.Use(func(c *fiber.Ctx) error {
@@ -3,6 +3,7 @@ package errmsg
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -14,6 +15,8 @@ func errorValidationHandler[T any](err error, payload *T) (int, map[string][]str
 		code          = 400
 	)
 
+	embedded := embeddedNames(reflect.TypeOf(payload).Elem(), map[string]bool{})
+
 	for _, err := range err.(validator.ValidationErrors) {
 		var (
 			// Get the JSON tag name
@@ -29,6 +32,9 @@ func errorValidationHandler[T any](err error, payload *T) (int, map[string][]str
 
 			// Get the error message
 		)
+		// remove embedded struct names
+		fieldParts = slices.DeleteFunc(fieldParts, func(part string) bool { return embedded[part] })
+
 		lastField := fieldParts[len(fieldParts)-1]                                // get the last element
 		fieldParts = fieldParts[1:]                                               // remove the first element
 		field = strings.Join(fieldParts, ".")                                     // join the rest of the elements
@@ -138,29 +144,13 @@ func errorValidationHandler[T any](err error, payload *T) (int, map[string][]str
 			// message = fmt.Sprintf("%s must be a number.", fieldInMsg)
 			message = fmt.Sprintf("%s harus angka.", fieldInMsg)
 		case "eqfield":
-			eqField := err.Param()
-			eqFieldName := ""
-			eqFieldTag, _ := reflect.TypeOf(payload).Elem().FieldByName(eqField)
-			eqFieldJSONTag := eqFieldTag.Tag.Get("json")
-			eqFieldQueryTag := eqFieldTag.Tag.Get("query")
-			eqFieldFormTag := eqFieldTag.Tag.Get("form")
-			eqFieldParamsTag := eqFieldTag.Tag.Get("params")
-
-			if eqFieldJSONTag != "" {
-				eqFieldName = strings.ReplaceAll(eqFieldJSONTag, "_", " ")
-			}
-			if eqFieldQueryTag != "" {
-				eqFieldName = strings.ReplaceAll(eqFieldQueryTag, "_", " ")
-			}
-			if eqFieldFormTag != "" {
-				eqFieldName = strings.ReplaceAll(eqFieldFormTag, "_", " ")
-			}
-			if eqFieldParamsTag != "" {
-				eqFieldName = strings.ReplaceAll(eqFieldParamsTag, "_", " ")
-			}
+			eqFieldName := fieldNameInMsg(payload, err.Param())
 
 			// message = fmt.Sprintf("%s must be equal to %s.", fieldInMsg, eqFieldName)
 			message = fmt.Sprintf("%s harus sama dengan %s.", fieldInMsg, eqFieldName)
+		case "gtfield":
+			// message = fmt.Sprintf("%s must be after %s.", fieldInMsg, err.Param())
+			message = fmt.Sprintf("%s harus lebih dari %s.", fieldInMsg, fieldNameInMsg(payload, err.Param()))
 		case "oneof":
 			// message = fmt.Sprintf("%s must be one of %s.", fieldInMsg, err.Param())
 			// message = fmt.Sprintf("%s harus salah satu dari %s.", fieldInMsg, err.Param())
@@ -171,6 +161,19 @@ func errorValidationHandler[T any](err error, payload *T) (int, map[string][]str
 			oneOfValues[len(oneOfValues)-1] = "atau " + oneOfValues[len(oneOfValues)-1]
 			oneOfValuesStr := strings.Join(oneOfValues, ", ")
 			message = fmt.Sprintf("%s harus salah satu dari %s.", fieldInMsg, oneOfValuesStr)
+		case "currency":
+			// message = fmt.Sprintf("%s is not a supported currency.", fieldInMsg)
+			message = fmt.Sprintf("%s bukan mata uang yang didukung.", fieldInMsg)
+		case "locale":
+			// message = fmt.Sprintf("%s is not a valid language tag.", fieldInMsg)
+			message = fmt.Sprintf("%s bukan kode bahasa yang valid.", fieldInMsg)
+		case "required_if":
+			// message = fmt.Sprintf("%s is required when %s is %s.", fieldInMsg, other, value)
+			params := strings.SplitN(err.Param(), " ", 2)
+			message = fmt.Sprintf("%s harus diisi jika %s adalah %s.", fieldInMsg, fieldNameInMsg(payload, params[0]), params[len(params)-1])
+		case "required_without":
+			// message = fmt.Sprintf("%s is required when %s is not present.", fieldInMsg, other)
+			message = fmt.Sprintf("%s harus diisi jika %s tidak diisi.", fieldInMsg, fieldNameInMsg(payload, err.Param()))
 		case "unique_in_slice":
 			// message = fmt.Sprintf("%s elements must be unique.", fieldInMsg)
 			message = fmt.Sprintf("elemen %s harus unik.", fieldInMsg)
@@ -181,3 +184,39 @@ func errorValidationHandler[T any](err error, payload *T) (int, map[string][]str
 
 	return code, errorMessages
 }
+
+// fieldNameInMsg returns the request name of a sibling struct field (used by
+// the *field tags), ex: "StartsAt" => "starts at".
+func fieldNameInMsg[T any](payload *T, field string) string {
+	var (
+		name        = ""
+		fieldTag, _ = reflect.TypeOf(payload).Elem().FieldByName(field)
+	)
+
+	for _, tag := range []string{"json", "query", "form", "params"} {
+		if v := fieldTag.Tag.Get(tag); v != "" {
+			name = strings.ReplaceAll(v, "_", " ")
+		}
+	}
+
+	return name
+}
+
+// embeddedNames collects the names of embedded structs in t. The validator
+// puts them in the namespace although they are not part of the request, ex:
+// "CreateVoucherRequest.VoucherRequest.code".
+func embeddedNames(t reflect.Type, names map[string]bool) map[string]bool {
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			names[f.Name] = true
+			embeddedNames(f.Type, names)
+		}
+	}
+
+	return names
+}
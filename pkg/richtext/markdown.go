@@ -0,0 +1,149 @@
+package richtext
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// renderMarkdown supports the subset of Markdown sellers use: headings,
+// paragraphs, emphasis, inline and fenced code, links, lists, block quotes
+// and horizontal rules. Line breaks inside a paragraph are kept. The output
+// is not safe until it is sanitized.
+func renderMarkdown(src string) string {
+	var (
+		b     strings.Builder
+		lines = strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+		para  = make([]string, 0)
+		list  = ""
+	)
+
+	flushPara := func() {
+		if len(para) > 0 {
+			b.WriteString("<p>" + strings.Join(para, "<br>") + "</p>")
+			para = para[:0]
+		}
+	}
+	closeList := func() {
+		if list != "" {
+			b.WriteString("</" + list + ">")
+			list = ""
+		}
+	}
+	openList := func(tag string) {
+		if list != tag {
+			closeList()
+			b.WriteString("<" + tag + ">")
+			list = tag
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flushPara()
+			closeList()
+			code := make([]string, 0)
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, html.EscapeString(lines[i]))
+			}
+			b.WriteString("<pre><code>" + strings.Join(code, "\n") + "</code></pre>")
+
+		case trimmed == "":
+			flushPara()
+			closeList()
+
+		case mdRule.MatchString(trimmed):
+			flushPara()
+			closeList()
+			b.WriteString("<hr>")
+
+		case mdHeading.MatchString(trimmed):
+			flushPara()
+			closeList()
+			m := mdHeading.FindStringSubmatch(trimmed)
+			tag := "h" + strconv.Itoa(len(m[1]))
+			b.WriteString("<" + tag + ">" + renderInline(m[2]) + "</" + tag + ">")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushPara()
+			closeList()
+			quote := make([]string, 0)
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				text := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quote = append(quote, renderInline(strings.TrimSpace(text)))
+			}
+			i--
+			b.WriteString("<blockquote><p>" + strings.Join(quote, "<br>") + "</p></blockquote>")
+
+		case mdBullet.MatchString(trimmed):
+			flushPara()
+			openList("ul")
+			b.WriteString("<li>" + renderInline(mdBullet.FindStringSubmatch(trimmed)[1]) + "</li>")
+
+		case mdOrdered.MatchString(trimmed):
+			flushPara()
+			openList("ol")
+			b.WriteString("<li>" + renderInline(mdOrdered.FindStringSubmatch(trimmed)[1]) + "</li>")
+
+		default:
+			closeList()
+			para = append(para, renderInline(trimmed))
+		}
+	}
+
+	flushPara()
+	closeList()
+
+	return b.String()
+}
+
+var (
+	mdHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdRule    = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	mdBullet  = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	mdOrdered = regexp.MustCompile(`^[0-9]+[.)]\s+(.*)$`)
+
+	mdCode   = regexp.MustCompile("`([^`]+)`")
+	mdLink   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+&#34;([^&]*)&#34;)?\)`)
+	mdStrong = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdEm     = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// renderInline escapes text and applies the inline markup. Code spans are
+// left as they are written.
+func renderInline(text string) string {
+	var (
+		b    strings.Builder
+		last = 0
+	)
+
+	for _, m := range mdCode.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(renderEmphasis(text[last:m[0]]))
+		b.WriteString("<code>" + html.EscapeString(text[m[2]:m[3]]) + "</code>")
+		last = m[1]
+	}
+	b.WriteString(renderEmphasis(text[last:]))
+
+	return b.String()
+}
+
+func renderEmphasis(text string) string {
+	text = html.EscapeString(text)
+	text = mdLink.ReplaceAllStringFunc(text, func(s string) string {
+		m := mdLink.FindStringSubmatch(s)
+		link := `<a href="` + m[2] + `"`
+		if m[3] != "" {
+			link += ` title="` + m[3] + `"`
+		}
+		return link + ">" + m[1] + "</a>"
+	})
+	text = mdStrong.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = mdEm.ReplaceAllString(text, "<em>$1$2</em>")
+
+	return text
+}
@@ -0,0 +1,112 @@
+// Package richtext turns seller written descriptions into HTML that is safe
+// to render on the storefront.
+package richtext
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Render converts src written in format to sanitized HTML.
+func Render(format, src string) (string, error) {
+	switch format {
+	case FormatPlain, "":
+		return renderPlain(src), nil
+	case FormatMarkdown:
+		return Sanitize(renderMarkdown(src)), nil
+	case FormatHTML:
+		return Sanitize(src), nil
+	default:
+		return "", fmt.Errorf("richtext: unknown format %q", format)
+	}
+}
+
+// renderPlain escapes src and keeps its paragraphs and line breaks.
+func renderPlain(src string) string {
+	var b strings.Builder
+
+	for _, para := range paragraphs(src) {
+		lines := strings.Split(para, "\n")
+		for i := range lines {
+			lines[i] = html.EscapeString(lines[i])
+		}
+		b.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>")
+	}
+
+	return b.String()
+}
+
+func paragraphs(src string) []string {
+	var (
+		resp    = make([]string, 0)
+		current = make([]string, 0)
+	)
+
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				resp = append(resp, strings.Join(current, "\n"))
+				current = current[:0]
+			}
+			continue
+		}
+		current = append(current, strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+	if len(current) > 0 {
+		resp = append(resp, strings.Join(current, "\n"))
+	}
+
+	return resp
+}
+
+// blockTags end a run of text in an excerpt.
+var blockTags = map[atom.Atom]bool{
+	atom.P: true, atom.Br: true, atom.Hr: true, atom.Li: true, atom.Blockquote: true, atom.Pre: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Tr: true, atom.Td: true, atom.Th: true,
+}
+
+// Excerpt returns the text of safeHTML on one line, cut at a word boundary
+// to at most max characters including the trailing ellipsis.
+func Excerpt(safeHTML string, max int) string {
+	var b strings.Builder
+
+	var walk func(n *nethtml.Node)
+	walk = func(n *nethtml.Node) {
+		if n.Type == nethtml.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == nethtml.ElementNode && blockTags[n.DataAtom] {
+			b.WriteString(" ")
+		}
+	}
+	for _, n := range parse(safeHTML) {
+		walk(n)
+	}
+
+	text := []rune(strings.Join(strings.Fields(b.String()), " "))
+	if len(text) <= max {
+		return string(text)
+	}
+
+	cut := text[:max-1]
+	if i := strings.LastIndexFunc(string(cut), unicode.IsSpace); i > 0 {
+		cut = []rune(string(cut)[:i])
+	}
+
+	return strings.TrimRightFunc(string(cut), func(r rune) bool { return unicode.IsSpace(r) || unicode.IsPunct(r) }) + "…"
+}
@@ -0,0 +1,51 @@
+package richtext
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`<p onclick="x()">Hi <b>there</b></p>`, `<p>Hi <b>there</b></p>`},
+		{`<script>alert(1)</script>ok`, `ok`},
+		{`<a href="javascript:alert(1)">x</a>`, `<a rel="nofollow noopener noreferrer ugc">x</a>`},
+		{`<a href="https://example.com/?a=1&b=2" target="_blank">x</a>`, `<a href="https://example.com/?a=1&amp;b=2" rel="nofollow noopener noreferrer ugc">x</a>`},
+		{`<div><img src=x onerror=alert(1)>text</div>`, `text`},
+		{`1 < 2 & <i>3</i>`, `1 &lt; 2 &amp; <i>3</i>`},
+	}
+
+	for _, tt := range tests {
+		if got := Sanitize(tt.src); got != tt.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		format string
+		src    string
+		want   string
+	}{
+		{FormatPlain, "a <b>\nc\n\nd", `<p>a &lt;b&gt;<br>c</p><p>d</p>`},
+		{FormatMarkdown, "## Fitur\n- **cepat**\n- `<x>`\n\n[web](javascript:x)", `<h2>Fitur</h2><ul><li><strong>cepat</strong></li><li><code>&lt;x&gt;</code></li></ul><p><a rel="nofollow noopener noreferrer ugc">web</a></p>`},
+		{FormatHTML, `<h2>Hi</h2><iframe src="x"></iframe>`, `<h2>Hi</h2>`},
+	}
+
+	for _, tt := range tests {
+		got, err := Render(tt.format, tt.src)
+		if err != nil || got != tt.want {
+			t.Errorf("Render(%q, %q) = %q, %v, want %q", tt.format, tt.src, got, err, tt.want)
+		}
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	if got := Excerpt(`<h2>Tas</h2><p>Kulit asli, awet dan kuat</p>`, 100); got != "Tas Kulit asli, awet dan kuat" {
+		t.Errorf("Excerpt() = %q", got)
+	}
+	if got := Excerpt(`<p>Kulit asli, awet dan kuat</p>`, 14); got != "Kulit asli…" {
+		t.Errorf("Excerpt() = %q", got)
+	}
+}
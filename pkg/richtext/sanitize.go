@@ -0,0 +1,171 @@
+package richtext
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedTags are the elements kept by Sanitize, with the attributes each
+// may keep. Other elements are unwrapped: their text stays.
+var allowedTags = map[atom.Atom][]string{
+	atom.P:          nil,
+	atom.Br:         nil,
+	atom.Hr:         nil,
+	atom.H1:         nil,
+	atom.H2:         nil,
+	atom.H3:         nil,
+	atom.H4:         nil,
+	atom.H5:         nil,
+	atom.H6:         nil,
+	atom.Strong:     nil,
+	atom.B:          nil,
+	atom.Em:         nil,
+	atom.I:          nil,
+	atom.U:          nil,
+	atom.S:          nil,
+	atom.Ul:         nil,
+	atom.Ol:         nil,
+	atom.Li:         nil,
+	atom.Blockquote: nil,
+	atom.Code:       nil,
+	atom.Pre:        nil,
+	atom.Table:      nil,
+	atom.Thead:      nil,
+	atom.Tbody:      nil,
+	atom.Tr:         nil,
+	atom.Th:         nil,
+	atom.Td:         nil,
+	atom.A:          {"href", "title"},
+}
+
+// droppedTags are removed together with everything inside them.
+var droppedTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Iframe:   true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Textarea: true,
+	atom.Select:   true,
+	atom.Title:    true,
+	atom.Svg:      true,
+	atom.Math:     true,
+}
+
+var voidTags = map[atom.Atom]bool{
+	atom.Br: true,
+	atom.Hr: true,
+}
+
+var allowedSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// linkRel is set on every link, sellers' links are not endorsed.
+const linkRel = "nofollow noopener noreferrer ugc"
+
+// Sanitize returns src with only the allowlisted elements and attributes.
+// Links keep http, https and mailto URLs only.
+func Sanitize(src string) string {
+	var b strings.Builder
+
+	for _, n := range parse(src) {
+		writeNode(&b, n)
+	}
+
+	return b.String()
+}
+
+func parse(src string) []*html.Node {
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		// the tokenizer only fails on read errors, which a strings.Reader
+		// never returns
+		return nil
+	}
+
+	return nodes
+}
+
+func writeNode(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if droppedTags[n.DataAtom] {
+		return
+	}
+
+	attrs, ok := allowedTags[n.DataAtom]
+	if !ok {
+		writeChildren(b, n)
+		return
+	}
+
+	b.WriteString("<" + n.Data)
+	for _, a := range n.Attr {
+		if a.Namespace != "" || !contains(attrs, a.Key) {
+			continue
+		}
+		if a.Key == "href" {
+			href, ok := safeURL(a.Val)
+			if !ok {
+				continue
+			}
+			a.Val = href
+		}
+		b.WriteString(" " + a.Key + `="` + html.EscapeString(a.Val) + `"`)
+	}
+	if n.DataAtom == atom.A {
+		b.WriteString(` rel="` + linkRel + `"`)
+	}
+	b.WriteString(">")
+
+	if voidTags[n.DataAtom] {
+		return
+	}
+
+	writeChildren(b, n)
+	b.WriteString("</" + n.Data + ">")
+}
+
+func writeChildren(b *strings.Builder, n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeNode(b, c)
+	}
+}
+
+func safeURL(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || !allowedSchemes[strings.ToLower(u.Scheme)] {
+		return "", false
+	}
+
+	return u.String(), true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
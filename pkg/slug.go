@@ -0,0 +1,48 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// SlugMaxLength is the longest slug Slugify returns, leaving room in a
+// VARCHAR(120) column for a "-<n>" suffix that makes it unique.
+const SlugMaxLength = 100
+
+// Slugify turns a name into the lower case ASCII words of an SEO friendly
+// URL, ex: "Kaos Polos Katun Combed 30s (Putih)" => "kaos-polos-katun-combed-30s-putih".
+// Accents are dropped, ex: "Café" => "cafe". It returns "" when s has no
+// letters or digits.
+func Slugify(s string) string {
+	var (
+		b    strings.Builder
+		dash bool
+	)
+
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// combining accent of the previous letter
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		default:
+			dash = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > SlugMaxLength {
+		slug = slug[:SlugMaxLength]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+
+	return strings.TrimRight(slug, "-")
+}
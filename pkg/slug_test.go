@@ -0,0 +1,26 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Kaos Polos Katun Combed 30s (Putih)", "kaos-polos-katun-combed-30s-putih"},
+		{"  Café  Crème ", "cafe-creme"},
+		{"--Sepatu__Lari--", "sepatu-lari"},
+		{"!!!", ""},
+		{"日本茶", ""},
+		{strings.Repeat("abcdefghi ", 15), strings.TrimSuffix(strings.Repeat("abcdefghi-", 10), "-")},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.name); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// NormalizeLocale writes a language tag the way translations are stored:
+// lower case with hyphens, ex: "en_US" => "en-us".
+func NormalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// IsValidLocale reports whether locale is a language tag like "en" or
+// "en-US".
+func IsValidLocale(locale string) bool {
+	return localePattern.MatchString(NormalizeLocale(locale))
+}
+
+// PreferredLocales turns an Accept-Language header into the normalized
+// locales to look translations up in, best first. Each region tag is
+// followed by its language, ex: "en-US,id;q=0.5" => [en-us en id].
+//
+// original is the language the content was written in. The list stops
+// before the first locale of that language because the original text is
+// then the best match.
+func PreferredLocales(acceptLanguage, original string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var (
+		ranges = make([]weighted, 0)
+		seen   = make(map[string]bool)
+		resp   = make([]string, 0)
+		base   = strings.SplitN(NormalizeLocale(original), "-", 2)[0]
+	)
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		var (
+			fields = strings.Split(part, ";")
+			locale = NormalizeLocale(fields[0])
+			q      = 1.0
+		)
+
+		for _, param := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					q = f
+				}
+			}
+		}
+
+		if q <= 0 || !localePattern.MatchString(locale) {
+			continue
+		}
+		ranges = append(ranges, weighted{locale, q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	for _, r := range ranges {
+		subtags := strings.Split(r.locale, "-")
+		if subtags[0] == base {
+			break
+		}
+
+		for i := len(subtags); i > 0; i-- {
+			locale := strings.Join(subtags[:i], "-")
+			if !seen[locale] {
+				seen[locale] = true
+				resp = append(resp, locale)
+			}
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPreferredLocales(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{"", []string{}},
+		{"en-US,en;q=0.9", []string{"en-us", "en"}},
+		{"id;q=0.2, ja, en_GB;q=0.8", []string{"ja", "en-gb", "en"}},
+		{"id-ID,en", []string{}},
+		{"en;q=0, *;q=0.5, fr", []string{"fr"}},
+	}
+
+	for _, tt := range tests {
+		if got := PreferredLocales(tt.header, "id"); !slices.Equal(got, tt.want) {
+			t.Errorf("PreferredLocales(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
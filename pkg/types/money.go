@@ -0,0 +1,334 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used for amounts that do not carry a currency, such as
+// the product.price column and legacy numeric JSON payloads.
+const DefaultCurrency = "IDR"
+
+// currencyExponents lists the number of minor units per currency (ISO 4217).
+var currencyExponents = map[string]int{
+	"IDR": 2,
+	"USD": 2,
+	"EUR": 2,
+	"SGD": 2,
+	"MYR": 2,
+	"THB": 2,
+	"PHP": 2,
+	"VND": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"CNY": 2,
+	"AUD": 2,
+	"GBP": 2,
+}
+
+var (
+	ErrInvalidMoney     = errors.New("invalid money amount")
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrTooManyDecimals  = errors.New("amount has more decimals than the currency allows")
+)
+
+// Money is an exact amount of a currency, stored as an integer number of
+// minor units (e.g. cents). The zero value is zero in DefaultCurrency.
+//
+// In JSON it is written as {"amount": <minor units>, "currency": "IDR"}. For
+// backward compatibility it also reads a bare number or decimal string in
+// major units, e.g. 150000 or "150000.50".
+//
+// As a database value it is the decimal amount in major units, matching the
+// DECIMAL price columns. Scanning keeps the currency already set on the
+// value, or DefaultCurrency.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// IsValidCurrency reports whether the ISO 4217 code is supported.
+func IsValidCurrency(currency string) bool {
+	_, ok := currencyExponents[strings.ToUpper(currency)]
+	return ok
+}
+
+// CurrencyExponent returns the number of minor units of the currency.
+func CurrencyExponent(currency string) (int, error) {
+	exp, ok := currencyExponents[strings.ToUpper(currency)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return exp, nil
+}
+
+// ParseMoney parses a decimal string in major units ("150000", "99.95").
+// More decimals than the currency allows is an error.
+func ParseMoney(s, currency string) (Money, error) {
+	return parseMoney(s, currency, false)
+}
+
+// parseMoney converts a decimal string to minor units. When round is set,
+// extra decimals are rounded half away from zero instead of rejected.
+func parseMoney(s, currency string, round bool) (Money, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	currency = strings.ToUpper(currency)
+
+	exp, err := CurrencyExponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)))
+	if !r.IsInt() {
+		if !round {
+			return Money{}, fmt.Errorf("%w: %q", ErrTooManyDecimals, s)
+		}
+		r = roundHalfAwayFromZero(r)
+	}
+
+	n := r.Num()
+	if !n.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+
+	return Money{Amount: n.Int64(), Currency: currency}, nil
+}
+
+func roundHalfAwayFromZero(r *big.Rat) *big.Rat {
+	half := big.NewRat(1, 2)
+	if r.Sign() < 0 {
+		half.Neg(half)
+	}
+
+	v := new(big.Rat).Add(r, half)
+	return new(big.Rat).SetInt(new(big.Int).Quo(v.Num(), v.Denom()))
+}
+
+func (m Money) currency() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+// String returns the amount in major units with the currency's decimals,
+// e.g. "150000.00".
+func (m Money) String() string {
+	exp, err := CurrencyExponent(m.currency())
+	if err != nil || exp == 0 {
+		return strconv.FormatInt(m.Amount, 10)
+	}
+
+	var (
+		sign   = ""
+		amount = m.Amount
+	)
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	pow := int64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/pow, exp, amount%pow)
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Cmp compares two amounts of the same currency, returning -1, 0 or +1.
+func (m Money) Cmp(o Money) (int, error) {
+	if m.currency() != o.currency() {
+		return 0, ErrCurrencyMismatch
+	}
+
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if m.currency() != o.currency() {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.currency()}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if m.currency() != o.currency() {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount - o.Amount, Currency: m.currency()}, nil
+}
+
+// Mul multiplies the amount by an integer quantity.
+func (m Money) Mul(qty int64) Money {
+	return Money{Amount: m.Amount * qty, Currency: m.currency()}
+}
+
+// MulRat multiplies the amount by an exact ratio (for percentages, rates and
+// taxes), rounding half away from zero to the nearest minor unit.
+func (m Money) MulRat(r *big.Rat) Money {
+	v := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), r)
+	if !v.IsInt() {
+		v = roundHalfAwayFromZero(v)
+	}
+	return Money{Amount: v.Num().Int64(), Currency: m.currency()}
+}
+
+// WithCurrency relabels the amount as currency, rescaling the minor units
+// when the exponents differ. It is used for amounts that were scanned before
+// their currency was known.
+func (m Money) WithCurrency(currency string) (Money, error) {
+	return m.Convert(currency, big.NewRat(1, 1))
+}
+
+// Convert returns the amount in currency at rate (units of currency per unit
+// of m's currency), rounded half away from zero to the minor unit of the
+// target currency.
+func (m Money) Convert(currency string, rate *big.Rat) (Money, error) {
+	from, err := CurrencyExponent(m.currency())
+	if err != nil {
+		return Money{}, err
+	}
+
+	to, err := CurrencyExponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	v := new(big.Rat).SetInt64(m.Amount)
+	v.Mul(v, rate)
+	v.Mul(v, new(big.Rat).SetFrac(pow10(to), pow10(from)))
+	v = roundHalfAwayFromZero(v)
+
+	n := v.Num()
+	if !n.IsInt64() {
+		return Money{}, fmt.Errorf("%w: conversion overflows", ErrInvalidMoney)
+	}
+
+	return NewMoney(n.Int64(), currency), nil
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+type moneyJSON struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Amount, Currency: m.currency()})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = []byte(strings.TrimSpace(string(data)))
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	if data[0] == '{' {
+		var v moneyJSON
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		if v.Currency == "" {
+			v.Currency = DefaultCurrency
+		}
+		if !IsValidCurrency(v.Currency) {
+			return fmt.Errorf("%w: %q", ErrUnknownCurrency, v.Currency)
+		}
+		*m = NewMoney(v.Amount, v.Currency)
+		return nil
+	}
+
+	// legacy form: major units as a number or a string
+	s := string(data)
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseMoney(s, m.currency())
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface so Money
+// can be used in query parameters, in major units.
+func (m *Money) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		return nil
+	}
+
+	parsed, err := ParseMoney(string(text), m.currency())
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (m *Money) Scan(src interface{}) error {
+	var s string
+
+	switch v := src.(type) {
+	case nil:
+		*m = Money{Currency: m.currency()}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidMoney, src)
+	}
+
+	parsed, err := parseMoney(s, m.currency(), true)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	m, err := ParseMoney("150000.5", "idr")
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(15000050, "IDR"), m)
+
+	m, err = ParseMoney("1500", "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500), m.Amount)
+
+	_, err = ParseMoney("0.001", "IDR")
+	assert.ErrorIs(t, err, ErrTooManyDecimals)
+
+	_, err = ParseMoney("abc", "IDR")
+	assert.ErrorIs(t, err, ErrInvalidMoney)
+
+	_, err = ParseMoney("1", "XYZ")
+	assert.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func TestMoneyString(t *testing.T) {
+	assert.Equal(t, "150000.00", NewMoney(15000000, "IDR").String())
+	assert.Equal(t, "-0.05", NewMoney(-5, "USD").String())
+	assert.Equal(t, "1500", NewMoney(1500, "JPY").String())
+	assert.Equal(t, "0.10", Money{Amount: 10}.String())
+}
+
+func TestMoneyScan(t *testing.T) {
+	var m Money
+	assert.NoError(t, m.Scan([]byte("199999.9950")))
+	assert.Equal(t, NewMoney(20000000, DefaultCurrency), m)
+
+	m = Money{Currency: "JPY"}
+	assert.NoError(t, m.Scan([]byte("1500.0000")))
+	assert.Equal(t, NewMoney(1500, "JPY"), m)
+}
+
+func TestMoneyJSON(t *testing.T) {
+	b, err := json.Marshal(NewMoney(15000000, "IDR"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":15000000,"currency":"IDR"}`, string(b))
+
+	var v struct {
+		Object Money `json:"object"`
+		Number Money `json:"number"`
+		String Money `json:"string"`
+	}
+	err = json.Unmarshal([]byte(`{"object":{"amount":995,"currency":"usd"},"number":150000,"string":"99.95"}`), &v)
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(995, "USD"), v.Object)
+	assert.Equal(t, NewMoney(15000000, "IDR"), v.Number)
+	assert.Equal(t, NewMoney(9995, "IDR"), v.String)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":1,"currency":"XYZ"}`), &v.Object))
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a := NewMoney(1000, "IDR")
+
+	sum, err := a.Add(NewMoney(250, "IDR"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1250), sum.Amount)
+
+	_, err = a.Add(NewMoney(1, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	assert.Equal(t, int64(3000), a.Mul(3).Amount)
+	assert.Equal(t, int64(111), NewMoney(1005, "IDR").MulRat(big.NewRat(11, 100)).Amount)
+	assert.Equal(t, int64(-111), NewMoney(-1005, "IDR").MulRat(big.NewRat(11, 100)).Amount)
+}
+
+func TestMoneyConvert(t *testing.T) {
+	usd, err := NewMoney(1500000000, "IDR").Convert("USD", big.NewRat(64, 1000000))
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(96000, "USD"), usd)
+
+	jpy, err := NewMoney(999, "USD").Convert("JPY", big.NewRat(14955, 100))
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(1494, "JPY"), jpy)
+
+	relabeled, err := NewMoney(150000, "IDR").WithCurrency("JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(1500, "JPY"), relabeled)
+
+	_, err = NewMoney(1, "IDR").Convert("XYZ", big.NewRat(1, 1))
+	assert.ErrorIs(t, err, ErrUnknownCurrency)
+}
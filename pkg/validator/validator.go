@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"codebase-app/pkg/types"
 	"reflect"
 	"strings"
 
@@ -62,6 +63,16 @@ func NewValidator() *Validator {
 	if err := v.RegisterValidation("unique_in_slice", isUniqueInSlice); err != nil {
 		log.Fatal().Err(err).Msg("Error while registering unique validator")
 	}
+	if err := v.RegisterValidation("currency", isCurrency); err != nil {
+		log.Fatal().Err(err).Msg("Error while registering currency validator")
+	}
+	if err := v.RegisterValidation("locale", isLocale); err != nil {
+		log.Fatal().Err(err).Msg("Error while registering locale validator")
+	}
+
+	// money fields are validated on their amount in minor units,
+	// ex: `validate:"required,gt=0"`
+	v.RegisterCustomTypeFunc(moneyAmount, types.Money{})
 
 	validatorCustom.validator = v
 	// validatorCustom.trans = trans
@@ -131,3 +142,18 @@ func isUniqueInSlice(fl validator.FieldLevel) bool {
 	}
 	return true
 }
+
+func isCurrency(fl validator.FieldLevel) bool {
+	return types.IsValidCurrency(fl.Field().String())
+}
+
+func isLocale(fl validator.FieldLevel) bool {
+	return types.IsValidLocale(fl.Field().String())
+}
+
+func moneyAmount(field reflect.Value) interface{} {
+	if m, ok := field.Interface().(types.Money); ok {
+		return m.Amount
+	}
+	return nil
+}